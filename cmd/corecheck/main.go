@@ -0,0 +1,14 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+// Command corecheck checks the use of the core.Error constructors in Go packages, e.g. corecheck ./pkg/...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/paulcarlton/go-utils/pkg/corecheck"
+)
+
+func main() {
+	singlechecker.Main(corecheck.Analyzer)
+}
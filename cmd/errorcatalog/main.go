@@ -0,0 +1,59 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+// Command errorcatalog writes a Markdown and json catalog of the core.Error values created in Go packages,
+// e.g. errorcatalog -markdown docs/errors.md -json docs/errors.json ./pkg/...
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/paulcarlton/go-utils/pkg/errorcatalog"
+)
+
+func main() {
+	markdown := flag.String("markdown", "", "file to write the Markdown catalog to")
+	jsonFile := flag.String("json", "", "file to write the json catalog to")
+	flag.Parse()
+
+	if err := run(*markdown, *jsonFile, flag.Args()); err != nil {
+		fmt.Fprintf(os.Stderr, "errorcatalog: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// run scans the packages and writes the catalogs, the Markdown catalog is written to stdout if no file is specified
+func run(markdown, jsonFile string, patterns []string) error {
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+	pkgs, err := errorcatalog.Scan(patterns...)
+	if err != nil {
+		return err
+	}
+	if len(markdown) == 0 && len(jsonFile) == 0 {
+		return errorcatalog.WriteMarkdown(os.Stdout, pkgs)
+	}
+	if err := writeFile(markdown, pkgs, errorcatalog.WriteMarkdown); err != nil {
+		return err
+	}
+	return writeFile(jsonFile, pkgs, errorcatalog.WriteJSON)
+}
+
+// writeFile writes a catalog to a file, nothing is written if the file name is empty
+func writeFile(name string, pkgs []errorcatalog.Package, write func(io.Writer, []errorcatalog.Package) error) error {
+	if len(name) == 0 {
+		return nil
+	}
+	file, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := write(file, pkgs); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
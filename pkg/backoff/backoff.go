@@ -0,0 +1,86 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+// Package backoff provides policies that compute the delay between attempts of an operation.
+// Policies hold the state of the sequence of delays so a policy should not be shared by concurrent
+// operations, use Reset to reuse a policy for a new operation.
+package backoff
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/paulcarlton/go-utils/pkg/core"
+)
+
+const (
+	id string = "backoff"
+
+	// ErrorStringMaxElapsed The maximum elapsed time of the policy has been reached
+	ErrorStringMaxElapsed string = "maximum elapsed time exceeded"
+)
+
+type (
+	// Policy defines the interface of a backoff policy
+	Policy interface {
+		// Next returns the delay before the next attempt, or false if the maximum elapsed time would be exceeded
+		Next() (time.Duration, bool)
+		// Wait sleeps for the next delay, returning early with an error if the context is done
+		Wait(ctx context.Context) error
+		// Reset restarts the sequence of delays and the elapsed time
+		Reset()
+	}
+
+	// elapsed tracks the time since the first delay of a policy was requested
+	elapsed struct {
+		start time.Time
+	}
+)
+
+// now returns the current time, tests replace it to control the elapsed time
+var now = time.Now
+
+// Sleep waits for a duration, returning a core.Error that is not retryable if the context is done first
+func Sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		err := core.RaiseError(id, core.ErrorServiceUnavailable, fmt.Sprintf("wait of %s cancelled", delay), ctx.Err())
+		return err.(core.Error).WithRetryable(false)
+	case <-timer.C:
+		return nil
+	}
+}
+
+// wait sleeps for the next delay of a policy
+func wait(ctx context.Context, policy Policy) error {
+	delay, ok := policy.Next()
+	if !ok {
+		err := core.MakeError(id, core.ErrorServiceUnavailable, ErrorStringMaxElapsed)
+		return err.(core.Error).WithRetryable(false)
+	}
+	return Sleep(ctx, delay)
+}
+
+// expired starts the elapsed time if it has not started and reports whether waiting for delay would
+// exceed max, a max of zero never expires
+func (e *elapsed) expired(delay, max time.Duration) bool {
+	if e.start.IsZero() {
+		e.start = now()
+	}
+	return max > 0 && now().Sub(e.start)+delay > max
+}
+
+// reset restarts the elapsed time
+func (e *elapsed) reset() {
+	e.start = time.Time{}
+}
+
+// bound limits a delay to max, a max of zero does not limit the delay
+func bound(delay, max time.Duration) time.Duration {
+	if max > 0 && delay > max {
+		return max
+	}
+	return delay
+}
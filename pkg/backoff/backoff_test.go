@@ -0,0 +1,50 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package backoff
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/paulcarlton/go-utils/pkg/core"
+	"github.com/paulcarlton/go-utils/pkg/testutils"
+)
+
+func TestWait(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var tests = []struct {
+		testNum  int
+		ctx      context.Context
+		policy   Policy
+		expected string
+	}{
+		{testNum: 1, ctx: context.Background(), policy: NewConstant(time.Millisecond, 0), expected: ""},
+		{testNum: 2, ctx: cancelled, policy: NewConstant(time.Hour, 0), expected: "wait of 1h0m0s cancelled"},
+		{testNum: 3, ctx: context.Background(), policy: NewConstant(time.Hour, time.Minute), expected: ErrorStringMaxElapsed},
+	}
+
+	for _, test := range tests {
+		err := test.policy.Wait(test.ctx)
+		message := ""
+		if coreErr, ok := err.(core.Error); ok {
+			message = coreErr.Message()
+		}
+		if message != test.expected || (err != nil && !core.IsPermanent(err)) || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%s\nGot.....:\n%s", test.testNum, test.expected, core.ErrorText(err))
+		}
+	}
+}
+
+func TestSleepCancelled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	err := Sleep(ctx, time.Hour)
+	coreErr, ok := err.(core.Error)
+	if !ok || coreErr.Code() != core.ErrorServiceUnavailable || !errors.Is(err, context.DeadlineExceeded) || testutils.FailTests {
+		t.Errorf("\nTest: 1\nExpected:\ncancelled wait\nGot.....:\n%s", core.ErrorText(err))
+	}
+}
@@ -0,0 +1,193 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package backoff
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+)
+
+const (
+	// DefaultMultiplier is the multiplier used by an Exponential policy with a Multiplier of zero
+	DefaultMultiplier float64 = 2
+
+	// DefaultInitial is the initial delay used by an Exponential policy with an Initial delay of zero
+	DefaultInitial = 100 * time.Millisecond
+)
+
+type (
+	// Constant is a policy that waits for the same delay before each attempt
+	Constant struct {
+		Delay      time.Duration
+		MaxElapsed time.Duration // MaxElapsed limits the total time spent waiting, zero for no limit
+		elapsed
+	}
+
+	// Exponential is a policy that starts with the Initial delay and multiplies it by the Multiplier
+	// before each subsequent attempt, up to the Max delay. Without a Max the delay stops increasing
+	// at the longest time.Duration.
+	Exponential struct {
+		Initial    time.Duration
+		Max        time.Duration // Max limits the delay, zero for no limit
+		Multiplier float64
+		MaxElapsed time.Duration // MaxElapsed limits the total time spent waiting, zero for no limit
+		current    time.Duration
+		elapsed
+	}
+
+	// DecorrelatedJitter is a policy that picks a random delay between the Base delay and three times the
+	// previous delay, up to the Max delay. This spreads out the attempts of clients that failed together.
+	DecorrelatedJitter struct {
+		Base       time.Duration
+		Max        time.Duration // Max limits the delay, zero for no limit
+		MaxElapsed time.Duration // MaxElapsed limits the total time spent waiting, zero for no limit
+		current    time.Duration
+		elapsed
+	}
+
+	// Fibonacci is a policy whose delays are the Initial delay multiplied by the Fibonacci sequence,
+	// 1, 1, 2, 3, 5..., up to the Max delay
+	Fibonacci struct {
+		Initial    time.Duration
+		Max        time.Duration // Max limits the delay, zero for no limit
+		MaxElapsed time.Duration // MaxElapsed limits the total time spent waiting, zero for no limit
+		previous   time.Duration
+		current    time.Duration
+		elapsed
+	}
+)
+
+// randInt63n returns a random number in [0,n), tests replace it to make jitter predictable
+var randInt63n = rand.Int63n
+
+// NewConstant creates a Constant policy
+func NewConstant(delay, maxElapsed time.Duration) *Constant {
+	return &Constant{Delay: delay, MaxElapsed: maxElapsed}
+}
+
+// Next returns the delay before the next attempt, or false if the maximum elapsed time would be exceeded
+func (p *Constant) Next() (time.Duration, bool) {
+	if p.expired(p.Delay, p.MaxElapsed) {
+		return 0, false
+	}
+	return p.Delay, true
+}
+
+// Wait sleeps for the next delay, returning early with an error if the context is done
+func (p *Constant) Wait(ctx context.Context) error {
+	return wait(ctx, p)
+}
+
+// Reset restarts the elapsed time
+func (p *Constant) Reset() {
+	p.reset()
+}
+
+// NewExponential creates an Exponential policy
+func NewExponential(initial, max time.Duration, multiplier float64, maxElapsed time.Duration) *Exponential {
+	return &Exponential{Initial: initial, Max: max, Multiplier: multiplier, MaxElapsed: maxElapsed}
+}
+
+// Next returns the delay before the next attempt, or false if the maximum elapsed time would be exceeded
+func (p *Exponential) Next() (time.Duration, bool) {
+	initial := p.Initial
+	if initial == 0 {
+		initial = DefaultInitial
+	}
+	delay := bound(initial, p.Max)
+	if p.current > 0 {
+		multiplier := p.Multiplier
+		if multiplier == 0 {
+			multiplier = DefaultMultiplier
+		}
+		next := time.Duration(math.MaxInt64)
+		if product := float64(p.current) * multiplier; product < float64(math.MaxInt64) {
+			next = time.Duration(product)
+		}
+		delay = bound(next, p.Max)
+	}
+	if p.expired(delay, p.MaxElapsed) {
+		return 0, false
+	}
+	p.current = delay
+	return delay, true
+}
+
+// Wait sleeps for the next delay, returning early with an error if the context is done
+func (p *Exponential) Wait(ctx context.Context) error {
+	return wait(ctx, p)
+}
+
+// Reset restarts the sequence of delays and the elapsed time
+func (p *Exponential) Reset() {
+	p.current = 0
+	p.reset()
+}
+
+// NewDecorrelatedJitter creates a DecorrelatedJitter policy
+func NewDecorrelatedJitter(base, max, maxElapsed time.Duration) *DecorrelatedJitter {
+	return &DecorrelatedJitter{Base: base, Max: max, MaxElapsed: maxElapsed}
+}
+
+// Next returns the delay before the next attempt, or false if the maximum elapsed time would be exceeded
+func (p *DecorrelatedJitter) Next() (time.Duration, bool) {
+	previous := p.current
+	if previous < p.Base {
+		previous = p.Base
+	}
+	delay := p.Base
+	if spread := int64(previous*3 - p.Base); spread > 0 {
+		delay += time.Duration(randInt63n(spread))
+	}
+	delay = bound(delay, p.Max)
+	if p.expired(delay, p.MaxElapsed) {
+		return 0, false
+	}
+	p.current = delay
+	return delay, true
+}
+
+// Wait sleeps for the next delay, returning early with an error if the context is done
+func (p *DecorrelatedJitter) Wait(ctx context.Context) error {
+	return wait(ctx, p)
+}
+
+// Reset restarts the sequence of delays and the elapsed time
+func (p *DecorrelatedJitter) Reset() {
+	p.current = 0
+	p.reset()
+}
+
+// NewFibonacci creates a Fibonacci policy
+func NewFibonacci(initial, max, maxElapsed time.Duration) *Fibonacci {
+	return &Fibonacci{Initial: initial, Max: max, MaxElapsed: maxElapsed}
+}
+
+// Next returns the delay before the next attempt, or false if the maximum elapsed time would be exceeded
+func (p *Fibonacci) Next() (time.Duration, bool) {
+	next := p.Initial
+	if p.current > 0 {
+		next = p.previous + p.current
+	}
+	delay := bound(next, p.Max)
+	if p.expired(delay, p.MaxElapsed) {
+		return 0, false
+	}
+	if delay == next {
+		p.previous, p.current = p.current, next
+	}
+	return delay, true
+}
+
+// Wait sleeps for the next delay, returning early with an error if the context is done
+func (p *Fibonacci) Wait(ctx context.Context) error {
+	return wait(ctx, p)
+}
+
+// Reset restarts the sequence of delays and the elapsed time
+func (p *Fibonacci) Reset() {
+	p.previous, p.current = 0, 0
+	p.reset()
+}
@@ -0,0 +1,113 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package backoff
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/paulcarlton/go-utils/pkg/testutils"
+)
+
+// nextDelays returns the delays from calling Next count times, stopping when the policy is exhausted
+func nextDelays(policy Policy, count int) []time.Duration {
+	delays := []time.Duration{}
+	for i := 0; i < count; i++ {
+		delay, ok := policy.Next()
+		if !ok {
+			break
+		}
+		delays = append(delays, delay)
+	}
+	return delays
+}
+
+// compareDelays compares two lists of delays
+func compareDelays(one, two []time.Duration) bool {
+	if len(one) != len(two) {
+		return false
+	}
+	for index := range one {
+		if one[index] != two[index] {
+			return false
+		}
+	}
+	return true
+}
+
+// fakeClock replaces the clock with one that only advances when the delays are waited for
+type fakeClock struct {
+	current time.Time
+}
+
+// install replaces the clock, the function returned restores it
+func (c *fakeClock) install() func() {
+	c.current = time.Unix(0, 0)
+	now = func() time.Time { return c.current }
+	return func() { now = time.Now }
+}
+
+func TestPolicies(t *testing.T) {
+	original := randInt63n
+	defer func() { randInt63n = original }()
+	randInt63n = func(n int64) int64 { return n - 1 }
+
+	var tests = []struct {
+		testNum  int
+		policy   Policy
+		expected []time.Duration
+	}{
+		{testNum: 1, policy: NewConstant(time.Second, 0),
+			expected: []time.Duration{time.Second, time.Second, time.Second}},
+		{testNum: 2, policy: NewExponential(time.Second, 5*time.Second, 0, 0),
+			expected: []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}},
+		{testNum: 3, policy: NewExponential(time.Second, 0, 3, 0),
+			expected: []time.Duration{time.Second, 3 * time.Second, 9 * time.Second}},
+		{testNum: 4, policy: NewDecorrelatedJitter(time.Second, 10*time.Second, 0),
+			expected: []time.Duration{3*time.Second - 1, 9*time.Second - 4, 10 * time.Second}},
+		{testNum: 5, policy: NewFibonacci(time.Second, 6*time.Second, 0),
+			expected: []time.Duration{time.Second, time.Second, 2 * time.Second, 3 * time.Second, 5 * time.Second, 6 * time.Second, 6 * time.Second}},
+		{testNum: 6, policy: &Exponential{}, expected: []time.Duration{DefaultInitial, 2 * DefaultInitial}},
+		{testNum: 7, policy: NewExponential(math.MaxInt64/4, 0, 10, 0),
+			expected: []time.Duration{math.MaxInt64 / 4, math.MaxInt64, math.MaxInt64}},
+	}
+
+	for _, test := range tests {
+		result := nextDelays(test.policy, len(test.expected))
+		if !compareDelays(result, test.expected) || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%v\nGot.....:\n%v", test.testNum, test.expected, result)
+		}
+		test.policy.Reset()
+		if result := nextDelays(test.policy, 1); len(result) != 1 || result[0] != test.expected[0] || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected after reset:\n%v\nGot.....:\n%v", test.testNum, test.expected[0], result)
+		}
+	}
+}
+
+func TestPoliciesMaxElapsed(t *testing.T) {
+	clock := &fakeClock{}
+	defer clock.install()()
+
+	var tests = []struct {
+		testNum  int
+		policy   Policy
+		expected []time.Duration
+	}{
+		{testNum: 1, policy: NewConstant(time.Second, 3*time.Second), expected: []time.Duration{time.Second, time.Second, time.Second}},
+		{testNum: 2, policy: NewExponential(time.Second, 0, 2, 8*time.Second), expected: []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}},
+		{testNum: 3, policy: NewFibonacci(time.Second, 0, 5*time.Second), expected: []time.Duration{time.Second, time.Second, 2 * time.Second}},
+		{testNum: 4, policy: NewDecorrelatedJitter(time.Second, time.Second, 1500*time.Millisecond), expected: []time.Duration{time.Second}},
+	}
+
+	for _, test := range tests {
+		result := []time.Duration{}
+		for delay, ok := test.policy.Next(); ok; delay, ok = test.policy.Next() {
+			result = append(result, delay)
+			clock.current = clock.current.Add(delay)
+		}
+		if !compareDelays(result, test.expected) || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%v\nGot.....:\n%v", test.testNum, test.expected, result)
+		}
+	}
+}
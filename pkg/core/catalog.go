@@ -0,0 +1,193 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package core
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"sync"
+	"text/template"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	// DefaultLocale is the locale used to render messages when no locale is specified
+	DefaultLocale = "en"
+
+	// codeTextKey is the format of the catalog key of the text for a core error code
+	codeTextKey = "core.code.%d"
+)
+
+type (
+	// CatalogEntry holds the message and recommended action templates for an error key in a locale
+	// Templates use text/template syntax and are filled from the arguments supplied when the error is created
+	CatalogEntry struct {
+		Message            string   `json:"message" yaml:"message"`
+		RecommendedActions []string `json:"recommendedActions,omitempty" yaml:"recommendedActions,omitempty"`
+	}
+
+	// Catalog maps error keys to message and recommended action templates per locale
+	Catalog struct {
+		lock    sync.RWMutex
+		entries map[string]map[string]CatalogEntry // locale -> key -> entry
+	}
+
+	// catalogFile is the format of a catalog file, each file holds the entries for one locale
+	catalogFile struct {
+		Locale   string                  `json:"locale" yaml:"locale"`
+		Messages map[string]CatalogEntry `json:"messages" yaml:"messages"`
+	}
+)
+
+//go:embed catalog
+var catalogFiles embed.FS
+
+var defaultCatalog = NewCatalog()
+
+func init() {
+	if err := defaultCatalog.LoadFS(catalogFiles, "catalog"); err != nil {
+		panic(ErrorText(err))
+	}
+}
+
+// DefaultCatalog returns the catalog used by core.Error
+// Libraries can add their own entries to it using Add or LoadFS
+func DefaultCatalog() *Catalog {
+	return defaultCatalog
+}
+
+// NewCatalog creates an empty catalog
+func NewCatalog() *Catalog {
+	return &Catalog{entries: map[string]map[string]CatalogEntry{}}
+}
+
+// Add adds an entry to the catalog for a locale, replacing any existing entry for the key
+func (c *Catalog) Add(locale, key string, entry CatalogEntry) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.entries[locale] == nil {
+		c.entries[locale] = map[string]CatalogEntry{}
+	}
+	entry.RecommendedActions = append([]string{}, entry.RecommendedActions...)
+	c.entries[locale][key] = entry
+}
+
+// Load adds the entries in a catalog file to the catalog, the format is determined from the file name
+// extension which must be .json, .yaml or .yml
+func (c *Catalog) Load(name string, data []byte) error {
+	file := &catalogFile{}
+	var err error
+	switch path.Ext(name) {
+	case ".json":
+		err = json.Unmarshal(data, file)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, file)
+	default:
+		return MakeError(name, ErrorInvalidInput, "catalog file must be json or yaml")
+	}
+	if err != nil {
+		return RaiseError(name, ErrorInvalidInput, "failed to parse catalog file", err)
+	}
+	if len(file.Locale) == 0 {
+		return MakeError(name, ErrorInvalidInput, "catalog file does not specify a locale")
+	}
+	for key, entry := range file.Messages {
+		c.Add(file.Locale, key, entry)
+	}
+	return nil
+}
+
+// LoadFS adds the entries in all the json and yaml catalog files in a directory of a file system to the catalog
+func (c *Catalog) LoadFS(fsys fs.FS, dir string) error {
+	files, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return RaiseError(dir, ErrorInvalidInput, "failed to read catalog directory", err)
+	}
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		name := path.Join(dir, file.Name())
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return RaiseError(name, ErrorInvalidInput, "failed to read catalog file", err)
+		}
+		if err := c.Load(name, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Lookup returns the entry for a key in a locale. If there is no entry for the locale, the language of
+// the locale, such as "fr" for "fr-CA", and then the DefaultLocale are tried.
+func (c *Catalog) Lookup(locale, key string) (CatalogEntry, bool) {
+	for _, candidate := range localeCandidates(locale) {
+		if entry, ok := c.lookupExact(candidate, key); ok {
+			return entry, true
+		}
+	}
+	return CatalogEntry{}, false
+}
+
+// Render returns the message and recommended actions for a key in a locale, with the templates filled
+// from args. It returns false if there is no entry for the key.
+func (c *Catalog) Render(locale, key string, args Fields) (string, []string, bool) {
+	entry, ok := c.Lookup(locale, key)
+	if !ok {
+		return "", nil, false
+	}
+	actions := make([]string, 0, len(entry.RecommendedActions))
+	for _, action := range entry.RecommendedActions {
+		actions = append(actions, renderTemplate(action, args))
+	}
+	return renderTemplate(entry.Message, args), actions, true
+}
+
+// lookupExact returns the entry for a key in a locale without trying other locales
+func (c *Catalog) lookupExact(locale, key string) (CatalogEntry, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	entry, ok := c.entries[locale][key]
+	return entry, ok
+}
+
+// localeCandidates returns the locales to try for a locale, most specific first
+func localeCandidates(locale string) []string {
+	candidates := []string{}
+	if len(locale) > 0 {
+		candidates = append(candidates, locale)
+		if index := strings.IndexAny(locale, "-_"); index > 0 {
+			candidates = append(candidates, locale[:index])
+		}
+	}
+	return append(candidates, DefaultLocale)
+}
+
+// renderTemplate fills a template from args, the template text is returned if it cannot be rendered
+func renderTemplate(text string, args Fields) string {
+	if !strings.Contains(text, "{{") {
+		return text
+	}
+	tmpl, err := template.New("").Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return text
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, map[string]interface{}(args)); err != nil {
+		return text
+	}
+	return buf.String()
+}
+
+// codeText returns the catalog text for a core error code in a locale
+func codeText(code int, locale string) (string, bool) {
+	entry, ok := defaultCatalog.Lookup(locale, fmt.Sprintf(codeTextKey, code))
+	return entry.Message, ok
+}
@@ -0,0 +1,100 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/paulcarlton/go-utils/pkg/testutils"
+)
+
+func TestCatalogLoad(t *testing.T) {
+	var tests = []struct {
+		testNum  int
+		name     string
+		data     string
+		expected int
+	}{
+		{testNum: 1, name: "test.json", data: `{"locale":"de","messages":{"test.key":{"message":"Test"}}}`, expected: 0},
+		{testNum: 2, name: "test.yaml", data: "locale: de\nmessages:\n  test.key:\n    message: Test\n", expected: 0},
+		{testNum: 3, name: "test.txt", data: "", expected: ErrorInvalidInput},
+		{testNum: 4, name: "test.json", data: `{"locale":`, expected: ErrorInvalidInput},
+		{testNum: 5, name: "test.yml", data: "messages: {}\n", expected: ErrorInvalidInput},
+	}
+
+	for _, test := range tests {
+		catalog := NewCatalog()
+		err := catalog.Load(test.name, []byte(test.data))
+		code := 0
+		if err != nil {
+			code = err.(Error).Code()
+		}
+		if code != test.expected || testutils.FailTests {
+			t.Errorf("\nTest: %d\nInput1..:\n%s\nExpected:\n%d\nGot.....:\n%s", test.testNum, test.data, test.expected, ErrorText(err))
+		}
+		if entry, ok := catalog.Lookup("de", "test.key"); code == 0 && (!ok || entry.Message != "Test") {
+			t.Errorf("\nTest: %d\nExpected:\nTest\nGot.....:\n%s", test.testNum, entry.Message)
+		}
+	}
+}
+
+func TestCatalogRender(t *testing.T) {
+	catalog := NewCatalog()
+	catalog.Add(DefaultLocale, "test.missing", CatalogEntry{
+		Message:            "{{.name}} not found",
+		RecommendedActions: []string{"create {{.name}}"},
+	})
+	catalog.Add("fr", "test.missing", CatalogEntry{Message: "{{.name}} introuvable"})
+	catalog.Add("fr-CA", "test.other", CatalogEntry{Message: "autre"})
+
+	var tests = []struct {
+		testNum  int
+		locale   string
+		key      string
+		expected string
+		actions  []string
+		found    bool
+	}{
+		{testNum: 1, locale: "", key: "test.missing", expected: "item not found", actions: []string{"create item"}, found: true},
+		{testNum: 2, locale: "fr", key: "test.missing", expected: "item introuvable", actions: []string{}, found: true},
+		{testNum: 3, locale: "fr-CA", key: "test.missing", expected: "item introuvable", actions: []string{}, found: true},
+		{testNum: 4, locale: "de", key: "test.missing", expected: "item not found", actions: []string{"create item"}, found: true},
+		{testNum: 5, locale: "fr", key: "test.other", expected: "", actions: nil, found: false},
+		{testNum: 6, locale: "fr_CA", key: "test.missing", expected: "item introuvable", actions: []string{}, found: true},
+	}
+
+	for _, test := range tests {
+		result, actions, found := catalog.Render(test.locale, test.key, Fields{"name": "item"})
+		if result != test.expected || found != test.found || !compareStringArray(actions, test.actions) || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%s %v %t\nGot.....:\n%s %v %t",
+				test.testNum, test.expected, test.actions, test.found, result, actions, found)
+		}
+	}
+}
+
+func TestCodeTextLocale(t *testing.T) {
+	var tests = []struct {
+		testNum  int
+		code     int
+		locale   string
+		expected string
+	}{
+		{testNum: 1, code: ErrorUnknown, locale: "", expected: "Unknown Error"},
+		{testNum: 2, code: ErrorNotFound, locale: "", expected: "Not Found"},
+		{testNum: 3, code: ErrorNotFound, locale: "fr", expected: "Introuvable"},
+		{testNum: 4, code: ErrorUnknown, locale: "fr-FR", expected: "Erreur inconnue"},
+		{testNum: 5, code: ErrorNotFound, locale: "de", expected: "Not Found"},
+		{testNum: 6, code: 999, locale: "fr", expected: ""},
+	}
+
+	for _, test := range tests {
+		result := CodeTextLocale(test.code, test.locale)
+		if result != test.expected || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%s\nGot.....:\n%s", test.testNum, test.expected, result)
+		}
+	}
+	if CodeText(ErrorUnknown) != CodeTextLocale(ErrorUnknown, DefaultLocale) || strings.Contains(CodeText(999), "core") {
+		t.Errorf("\nTest: 7\nExpected:\n%s\nGot.....:\n%s", CodeText(ErrorUnknown), CodeTextLocale(ErrorUnknown, DefaultLocale))
+	}
+}
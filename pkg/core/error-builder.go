@@ -0,0 +1,90 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package core
+
+import (
+	"github.com/paulcarlton/go-utils/pkg/internal/common"
+)
+
+// Builder constructs an immutable core.Error, e.g. core.New(core.ErrorNotFound).ID(name).Msg("not found").Build()
+// Each method returns a new Builder so a partially configured Builder can be shared and extended safely
+type Builder struct {
+	code    int
+	id      string
+	message string
+	details string
+	actions []string
+	fields  Fields
+	nested  error
+}
+
+// New returns a Builder for a core.Error with a code
+func New(code int) Builder {
+	return Builder{code: code}
+}
+
+// ID returns a copy of the Builder with the subject identifier set
+func (b Builder) ID(id string) Builder {
+	b.id = id
+	return b
+}
+
+// Msg returns a copy of the Builder with the message set
+func (b Builder) Msg(message string) Builder {
+	b.message = message
+	return b
+}
+
+// Details returns a copy of the Builder with the details set
+func (b Builder) Details(details string) Builder {
+	b.details = details
+	return b
+}
+
+// Actions returns a copy of the Builder with recommended actions added
+func (b Builder) Actions(actions ...string) Builder {
+	b.actions = append(append([]string{}, b.actions...), actions...)
+	return b
+}
+
+// Fields returns a copy of the Builder with fields added, replacing existing fields with the same key
+func (b Builder) Fields(fields Fields) Builder {
+	b.fields = b.fields.merge(fields)
+	return b
+}
+
+// Wrap returns a copy of the Builder with a nested error set, as for RaiseError the code of a nested core.Error
+// is used if the Builder's code is ErrorUnknown and the context fields and request identifiers are inherited
+func (b Builder) Wrap(err error) Builder {
+	b.nested = err
+	return b
+}
+
+// Build creates the core.Error, its mutator methods return an error while its With methods return a new
+// core.Error, so it can be shared between goroutines
+func (b Builder) Build() Error {
+	err, _ := addStack(b.build(common.GetCaller(4, true)), StackTracesEnabled()).(Error)
+	return err
+}
+
+// build creates the core.Error, setting the function and file/line to the value provided
+func (b Builder) build(where string) error {
+	var err error
+	if b.nested == nil {
+		err = makeError(b.id, b.code, b.message, where)
+	} else {
+		err = raiseError(b.id, b.code, b.message, where, b.nested)
+	}
+
+	if cerr, ok := err.(*cerror); ok {
+		// The code of the Builder takes precedence over the code of a nested core.Error
+		if b.code != ErrorUnknown {
+			cerr.code = b.code
+		}
+		cerr.details = b.details
+		cerr.recommendedActions = append(cerr.recommendedActions, b.actions...)
+		cerr.fields = cerr.fields.merge(b.fields)
+		cerr.immutable = true
+	}
+	return err
+}
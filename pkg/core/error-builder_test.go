@@ -0,0 +1,162 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package core
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/paulcarlton/go-utils/pkg/testutils"
+)
+
+func TestBuilder(t *testing.T) {
+	nested := MakeError("", ErrorServiceUnavailable, "backend down").(Error).WithField("namespace", "default")
+	base := New(ErrorNotFound).ID("abc").Msg("no such item")
+	var tests = []struct {
+		testNum  int
+		builder  Builder
+		expected *cerror
+	}{
+		{
+			testNum: 1,
+			builder: base.Details("item abc is missing").Actions("create item abc"),
+			expected: &cerror{
+				code:               ErrorNotFound,
+				message:            "no such item",
+				details:            "item abc is missing",
+				id:                 "abc",
+				where:              "core.TestBuilder() - error-builder_test.go(NN)",
+				recommendedActions: []string{"create item abc"},
+			},
+		},
+		{
+			testNum: 2,
+			builder: base.Wrap(nested).Fields(Fields{"attempt": 1}),
+			expected: &cerror{
+				code:               ErrorNotFound,
+				message:            "no such item",
+				id:                 "abc",
+				where:              "core.TestBuilder() - error-builder_test.go(NN)",
+				recommendedActions: []string{},
+				nestedError:        nested,
+				fields:             Fields{"namespace": "default", "attempt": 1},
+			},
+		},
+		{
+			testNum: 3,
+			builder: New(ErrorUnknown).Msg("failed to read").Wrap(nested),
+			expected: &cerror{
+				code:               ErrorServiceUnavailable,
+				message:            "failed to read",
+				where:              "core.TestBuilder() - error-builder_test.go(NN)",
+				recommendedActions: []string{},
+				nestedError:        nested,
+				fields:             Fields{"namespace": "default"},
+			},
+		},
+		{
+			testNum: 4,
+			builder: base,
+			expected: &cerror{
+				code:               ErrorNotFound,
+				message:            "no such item",
+				id:                 "abc",
+				where:              "core.TestBuilder() - error-builder_test.go(NN)",
+				recommendedActions: []string{},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		result := test.builder.Build()
+		if !CompareErrors(result, test.expected) || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%s\nGot.....:\n%s\n%s", test.testNum, test.expected.FullInfo(), ErrorText(result),
+				test.expected.DiffText(result))
+		}
+	}
+}
+
+func TestImmutableError(t *testing.T) {
+	built := New(ErrorNotFound).Msg("no such item").Actions("create item").Build()
+	var tests = []struct {
+		testNum int
+		mutate  func() error
+	}{
+		{testNum: 1, mutate: func() error { return built.SetMessage("changed") }},
+		{testNum: 2, mutate: func() error { return built.AddDetails("changed") }},
+		{testNum: 3, mutate: func() error { return built.AddRecommendedActions("changed") }},
+	}
+
+	for _, test := range tests {
+		err := test.mutate()
+		if err == nil || err.Error() != immutableErrorObject || built.Message() != "no such item" ||
+			len(built.Details()) > 0 || len(built.RecommendedActions()) != 1 || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%s\nGot.....:\n%v\n%s", test.testNum, immutableErrorObject, err, built.FullInfo())
+		}
+	}
+
+	changed := built.WithMessage("changed").WithDetails("details").WithRecommendedActions("retry")
+	if changed.Message() != "changed" || changed.Details() != "details" ||
+		!compareStringArray(changed.RecommendedActions(), []string{"create item", "retry"}) ||
+		built.Message() != "no such item" || len(built.RecommendedActions()) != 1 ||
+		changed.SetMessage("again") == nil || testutils.FailTests {
+		t.Errorf("\nTest: 4\nExpected:\nchanged copy\nGot.....:\n%s\n%s", changed.FullInfo(), built.FullInfo())
+	}
+
+	stacked := New(ErrorNotFound).Msg("no such item").Actions("create item").Build().(*cerror)
+	stacked.stack = []string{"core.TestImmutableError()"}
+	built.RecommendedActions()[0] = "changed"
+	stacked.StackTrace()[0] = "changed"
+	if built.RecommendedActions()[0] != "create item" || stacked.StackTrace()[0] != "core.TestImmutableError()" || testutils.FailTests {
+		t.Errorf("\nTest: 5\nExpected:\ncopies of recommended actions and stack trace\nGot.....:\n%s\n%s", built.FullInfo(), stacked.FullInfo())
+	}
+}
+
+func TestWithCopies(t *testing.T) {
+	original := MakeError("abc", ErrorNotFound, "no such item").(Error)
+	list := NewErrorList("", "failed to process items")
+	list.Append(original)
+	var tests = []struct {
+		testNum  int
+		original Error
+		expected string
+	}{
+		{testNum: 1, original: original, expected: "no such item"},
+		{testNum: 2, original: list, expected: "failed to process items"},
+	}
+
+	for _, test := range tests {
+		result := test.original.WithMessage("changed").WithDetails("details").WithRecommendedActions("retry")
+		if test.original.Message() != test.expected || len(test.original.Details()) > 0 ||
+			len(test.original.RecommendedActions()) > 0 || result.Message() != "changed" || result.Details() != "details" ||
+			!compareStringArray(result.RecommendedActions(), []string{"retry"}) ||
+			fmt.Sprintf("%T", result) != fmt.Sprintf("%T", test.original) || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%s\nGot.....:\n%s\n%s", test.testNum, test.expected, test.original.FullInfo(),
+				result.FullInfo())
+		}
+	}
+}
+
+func TestConcurrentMutators(t *testing.T) {
+	shared := MakeError("", ErrorInternal, "failed").(Error)
+	var wg sync.WaitGroup
+	for index := 0; index < 10; index++ {
+		wg.Add(2)
+		go func(index int) {
+			defer wg.Done()
+			_ = shared.SetMessage(fmt.Sprintf("failed %d", index))
+			_ = shared.AddRecommendedActions("retry")
+		}(index)
+		go func() {
+			defer wg.Done()
+			_ = shared.FullInfo()
+			_ = shared.WithDetails("details").Error()
+		}()
+	}
+	wg.Wait()
+
+	if len(shared.RecommendedActions()) != 10 || testutils.FailTests {
+		t.Errorf("\nTest: 1\nExpected:\n10 recommended actions\nGot.....:\n%s", shared.FullInfo())
+	}
+}
@@ -0,0 +1,78 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package core
+
+import (
+	"github.com/paulcarlton/go-utils/pkg/internal/common"
+)
+
+// MakeCatalogError creates a core.Error with the message and recommended actions of the catalog entry for
+// key, filling the templates from args. If there is no catalog entry the key is used as the message.
+// FullInfoLocale renders the message and recommended actions in other locales.
+func MakeCatalogError(id string, code int, key string, args Fields) error {
+	return addStack(makeCatalogError(id, code, key, args, common.GetCaller(4, true), nil), StackTracesEnabled())
+}
+
+// RaiseCatalogError creates a core.Error from a nested error with the message and recommended actions of
+// the catalog entry for key, filling the templates from args
+func RaiseCatalogError(id string, code int, key string, args Fields, nested interface{}) error {
+	return addStack(makeCatalogError(id, code, key, args, common.GetCaller(4, true), nested), StackTracesEnabled())
+}
+
+// makeCatalogError creates a core.Error from a catalog entry, with a nested error if nested is not nil
+func makeCatalogError(id string, code int, key string, args Fields, where string, nested interface{}) error {
+	msg, actions, ok := defaultCatalog.Render(DefaultLocale, key, args)
+	if !ok {
+		msg = key
+	}
+
+	var err error
+	if nested == nil {
+		err = makeError(id, code, msg, where)
+	} else {
+		err = raiseError(id, code, msg, where, nested)
+	}
+
+	if cerr, ok := err.(*cerror); ok {
+		cerr.messageKey = key
+		cerr.messageArgs = args.merge(nil)
+		if len(actions) > 0 {
+			cerr.recommendedActions = append(actions, cerr.recommendedActions...)
+			cerr.catalogActions = len(actions)
+		}
+	}
+	return err
+}
+
+// localeMessage returns the message rendered in a locale if it was created from the catalog
+func (e *cerror) localeMessage(locale string) string {
+	key := e.catalogKey()
+	if len(locale) == 0 || len(key) == 0 {
+		return e.Message()
+	}
+	if msg, _, ok := defaultCatalog.Render(locale, key, e.messageArgs); ok {
+		return msg
+	}
+	return e.Message()
+}
+
+// localeActions returns the recommended actions with those created from the catalog rendered in a locale
+func (e *cerror) localeActions(locale string) []string {
+	recommendedActions := e.RecommendedActions()
+	key := e.catalogKey()
+	if len(locale) == 0 || len(key) == 0 || e.catalogActions > len(recommendedActions) {
+		return recommendedActions
+	}
+	_, actions, ok := defaultCatalog.Render(locale, key, e.messageArgs)
+	if !ok {
+		return recommendedActions
+	}
+	return append(actions, recommendedActions[e.catalogActions:]...)
+}
+
+// catalogKey returns the catalog key of the message, which is cleared if the message is replaced
+func (e *cerror) catalogKey() string {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+	return e.messageKey
+}
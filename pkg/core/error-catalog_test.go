@@ -0,0 +1,101 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package core
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/paulcarlton/go-utils/pkg/testutils"
+)
+
+// addCatalogTestEntries adds entries used by the tests to the default catalog
+func addCatalogTestEntries() {
+	defaultCatalog.Add(DefaultLocale, "core.test.not-found", CatalogEntry{
+		Message:            "{{.name}} not found",
+		RecommendedActions: []string{"create {{.name}}"},
+	})
+	defaultCatalog.Add("fr", "core.test.not-found", CatalogEntry{
+		Message:            "{{.name}} introuvable",
+		RecommendedActions: []string{"créer {{.name}}"},
+	})
+}
+
+func TestMakeCatalogError(t *testing.T) {
+	addCatalogTestEntries()
+
+	var tests = []struct {
+		testNum  int
+		result   error
+		expected *cerror
+	}{
+		{
+			testNum: 1,
+			result:  MakeCatalogError("test1", ErrorNotFound, "core.test.not-found", Fields{"name": "item"}),
+			expected: &cerror{
+				id:                 "test1",
+				where:              "core.TestMakeCatalogError() - error-catalog_test.go(NN)",
+				code:               ErrorNotFound,
+				message:            "item not found",
+				recommendedActions: []string{"create item"},
+			},
+		},
+		{
+			testNum: 2,
+			result:  RaiseCatalogError("test1", ErrorNotFound, "core.test.unknown-key", nil, &cerror{code: ErrorNotFound, message: "nested"}),
+			expected: &cerror{
+				id:                 "test1",
+				where:              "core.TestMakeCatalogError() - error-catalog_test.go(NN)",
+				code:               ErrorNotFound,
+				message:            "core.test.unknown-key",
+				recommendedActions: []string{},
+				nestedError:        &cerror{code: ErrorNotFound, message: "nested"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		if !CompareErrors(test.result, test.expected) || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%s\nGot.....:\n%s", test.testNum, test.expected.FullInfo(), ErrorText(test.result))
+		}
+	}
+}
+
+func TestFullInfoLocale(t *testing.T) {
+	addCatalogTestEntries()
+
+	err := MakeCatalogError("test1", ErrorNotFound, "core.test.not-found", Fields{"name": "item"}).(*cerror)
+	err.where = "not available"
+	err.recommendedActions = append(err.recommendedActions, "retry")
+	list := newErrorList("list1", msg, "not available")
+	list.Append(err)
+	setErr := err.clone()
+	setErr.SetMessage("item replaced") // nolint: errcheck
+
+	var tests = []struct {
+		testNum  int
+		result   string
+		expected string
+	}{
+		{testNum: 1, result: err.FullInfoLocale(""),
+			expected: "not available test1 Not Found item not found\nRecommended actions...\ncreate item\nretry"},
+		{testNum: 2, result: err.FullInfoLocale("fr"),
+			expected: "not available test1 Introuvable item introuvable\nRecommended actions...\ncréer item\nretry"},
+		{testNum: 3, result: ErrorTextLocale(err, "de"),
+			expected: "not available test1 Not Found item not found\nRecommended actions...\ncreate item\nretry"},
+		{testNum: 4, result: list.FullInfoLocale("fr"),
+			expected: fmt.Sprintf("not available list1 Introuvable %s\nErrors...\n%s", msg, err.FullInfoLocale("fr"))},
+		{testNum: 5, result: (&cerror{where: "not available", code: ErrorUnknown, message: msg}).FullInfoLocale("fr"),
+			expected: fmt.Sprintf("not available Erreur inconnue %s", msg)},
+		{testNum: 6, result: err.WithMessage("item replaced").FullInfoLocale("fr"),
+			expected: "not available test1 Introuvable item replaced\nRecommended actions...\ncreate item\nretry"},
+		{testNum: 7, result: setErr.FullInfoLocale("fr"),
+			expected: "not available test1 Introuvable item replaced\nRecommended actions...\ncreate item\nretry"},
+	}
+
+	for _, test := range tests {
+		if test.result != test.expected || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%s\nGot.....:\n%s", test.testNum, test.expected, test.result)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package core
+
+import (
+	"fmt"
+
+	"github.com/paulcarlton/go-utils/pkg/internal/common"
+)
+
+// The constructors below create a core.Error with a code and a message formatted using fmt.Sprintf, the
+// predicates report whether an error or any error in its nested chain has a code, see HasCode.
+// There are no functions for ErrorUnknown since it is used for errors that cannot be categorized.
+
+// BadRequestf creates a core.Error with code ErrorBadRequest, indicating the request is invalid
+func BadRequestf(id, format string, args ...interface{}) error {
+	return addStack(makeError(id, ErrorBadRequest, fmt.Sprintf(format, args...), common.GetCaller(4, true)), StackTracesEnabled())
+}
+
+// IsBadRequest reports whether err or an error in its nested chain has code ErrorBadRequest
+func IsBadRequest(err error) bool {
+	return HasCode(err, ErrorBadRequest)
+}
+
+// Conflictf creates a core.Error with code ErrorDuplicateEntry, indicating an unexpected duplicate caused a conflict
+func Conflictf(id, format string, args ...interface{}) error {
+	return addStack(makeError(id, ErrorDuplicateEntry, fmt.Sprintf(format, args...), common.GetCaller(4, true)), StackTracesEnabled())
+}
+
+// IsConflict reports whether err or an error in its nested chain has code ErrorDuplicateEntry
+func IsConflict(err error) bool {
+	return HasCode(err, ErrorDuplicateEntry)
+}
+
+// Internalf creates a core.Error with code ErrorInternal, indicating an internal error occurred
+func Internalf(id, format string, args ...interface{}) error {
+	return addStack(makeError(id, ErrorInternal, fmt.Sprintf(format, args...), common.GetCaller(4, true)), StackTracesEnabled())
+}
+
+// IsInternal reports whether err or an error in its nested chain has code ErrorInternal
+func IsInternal(err error) bool {
+	return HasCode(err, ErrorInternal)
+}
+
+// InvalidInputf creates a core.Error with code ErrorInvalidInput, indicating an input item is invalid
+func InvalidInputf(id, format string, args ...interface{}) error {
+	return addStack(makeError(id, ErrorInvalidInput, fmt.Sprintf(format, args...), common.GetCaller(4, true)), StackTracesEnabled())
+}
+
+// IsInvalidInput reports whether err or an error in its nested chain has code ErrorInvalidInput
+func IsInvalidInput(err error) bool {
+	return HasCode(err, ErrorInvalidInput)
+}
+
+// NotFoundf creates a core.Error with code ErrorNotFound, indicating the item specified is not found
+func NotFoundf(id, format string, args ...interface{}) error {
+	return addStack(makeError(id, ErrorNotFound, fmt.Sprintf(format, args...), common.GetCaller(4, true)), StackTracesEnabled())
+}
+
+// IsNotFound reports whether err or an error in its nested chain has code ErrorNotFound
+func IsNotFound(err error) bool {
+	return HasCode(err, ErrorNotFound)
+}
+
+// NotAllowedf creates a core.Error with code ErrorNotAllowed, indicating the operation is not allowed
+func NotAllowedf(id, format string, args ...interface{}) error {
+	return addStack(makeError(id, ErrorNotAllowed, fmt.Sprintf(format, args...), common.GetCaller(4, true)), StackTracesEnabled())
+}
+
+// IsNotAllowed reports whether err or an error in its nested chain has code ErrorNotAllowed
+func IsNotAllowed(err error) bool {
+	return HasCode(err, ErrorNotAllowed)
+}
+
+// Unauthorizedf creates a core.Error with code ErrorUnauthorized, indicating the caller is not authorized to perform the operation
+func Unauthorizedf(id, format string, args ...interface{}) error {
+	return addStack(makeError(id, ErrorUnauthorized, fmt.Sprintf(format, args...), common.GetCaller(4, true)), StackTracesEnabled())
+}
+
+// IsUnauthorized reports whether err or an error in its nested chain has code ErrorUnauthorized
+func IsUnauthorized(err error) bool {
+	return HasCode(err, ErrorUnauthorized)
+}
+
+// Unavailablef creates a core.Error with code ErrorServiceUnavailable, indicating the service is unavailable at present
+func Unavailablef(id, format string, args ...interface{}) error {
+	return addStack(makeError(id, ErrorServiceUnavailable, fmt.Sprintf(format, args...), common.GetCaller(4, true)), StackTracesEnabled())
+}
+
+// IsUnavailable reports whether err or an error in its nested chain has code ErrorServiceUnavailable
+func IsUnavailable(err error) bool {
+	return HasCode(err, ErrorServiceUnavailable)
+}
+
+// NotImplementedf creates a core.Error with code ErrorNotImplemented, indicating the requested information or action is not implemented
+func NotImplementedf(id, format string, args ...interface{}) error {
+	return addStack(makeError(id, ErrorNotImplemented, fmt.Sprintf(format, args...), common.GetCaller(4, true)), StackTracesEnabled())
+}
+
+// IsNotImplemented reports whether err or an error in its nested chain has code ErrorNotImplemented
+func IsNotImplemented(err error) bool {
+	return HasCode(err, ErrorNotImplemented)
+}
+
+// HasCode reports whether err or an error in its nested chain is a core.Error with a code
+// An ErrorList matches if its derived code or any error in the list matches. Errors that are not a core.Error
+// are classified using the registered classifiers, so an error returned by another library matches if a
+// classifier for its type has been registered, see RegisterClassifier
+func HasCode(err error, code int) bool {
+	for ; err != nil; err = nestedError(err) {
+		if list, ok := err.(*ErrorList); ok {
+			for _, member := range list.Errors() {
+				if HasCode(member, code) {
+					return true
+				}
+			}
+		}
+		if coreErr, ok := err.(Error); ok {
+			if coreErr.Code() == code {
+				return true
+			}
+			continue
+		}
+		if Classify(&cerror{code: ErrorUnknown, nestedError: err}) == code {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/paulcarlton/go-utils/pkg/testutils"
+)
+
+func TestCodeConstructors(t *testing.T) {
+	var tests = []struct {
+		testNum   int
+		construct func(id, format string, args ...interface{}) error
+		predicate func(err error) bool
+		code      int
+	}{
+		{testNum: 1, construct: BadRequestf, predicate: IsBadRequest, code: ErrorBadRequest},
+		{testNum: 2, construct: Conflictf, predicate: IsConflict, code: ErrorDuplicateEntry},
+		{testNum: 3, construct: Internalf, predicate: IsInternal, code: ErrorInternal},
+		{testNum: 4, construct: InvalidInputf, predicate: IsInvalidInput, code: ErrorInvalidInput},
+		{testNum: 5, construct: NotFoundf, predicate: IsNotFound, code: ErrorNotFound},
+		{testNum: 6, construct: NotAllowedf, predicate: IsNotAllowed, code: ErrorNotAllowed},
+		{testNum: 7, construct: Unauthorizedf, predicate: IsUnauthorized, code: ErrorUnauthorized},
+		{testNum: 8, construct: Unavailablef, predicate: IsUnavailable, code: ErrorServiceUnavailable},
+		{testNum: 9, construct: NotImplementedf, predicate: IsNotImplemented, code: ErrorNotImplemented},
+	}
+
+	for _, test := range tests {
+		expected := &cerror{
+			code:               test.code,
+			id:                 "abc",
+			message:            "item abc failed 3 times",
+			where:              "core.TestCodeConstructors() - error-codes_test.go(NN)",
+			recommendedActions: []string{},
+		}
+		result := test.construct("abc", "item %s failed %d times", "abc", 3)
+		other := MakeError("", ErrorUnknown, "failed")
+		if !CompareErrors(result, expected) || !test.predicate(result) ||
+			!test.predicate(RaiseError("", ErrorInternal, "failed", fmt.Errorf("wrapped: %w", result))) ||
+			test.predicate(other) || test.predicate(nil) || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%s\nGot.....:\n%s\n%s", test.testNum, expected.FullInfo(), ErrorText(result),
+				expected.DiffText(result))
+		}
+	}
+}
+
+// notFound is an error type matched by a classifier registered in TestHasCode
+type notFound struct{}
+
+func (notFound) Error() string { return "resource is missing" }
+
+func TestHasCode(t *testing.T) {
+	defer restoreClassifiers(Classifiers())
+	RegisterClassifier(NestedTypeClassifier("test.not-found", ErrorNotFound, notFound{}))
+
+	list := NewErrorList("", "failed to process items")
+	list.Append(NotFoundf("", "no such item"))
+	mixed := NewErrorList("", "failed to process items")
+	mixed.Append(Internalf("", "failed"), RaiseError("", ErrorInternal, "failed", NotFoundf("", "no such item")))
+	var tests = []struct {
+		testNum  int
+		err      error
+		code     int
+		expected bool
+	}{
+		{testNum: 1, err: NotFoundf("", "no such item"), code: ErrorNotFound, expected: true},
+		{testNum: 2, err: RaiseError("", ErrorInternal, "failed", NotFoundf("", "no such item")), code: ErrorNotFound, expected: true},
+		{testNum: 3, err: New(ErrorInternal).Wrap(NotFoundf("", "no such item")).Build(), code: ErrorNotFound, expected: true},
+		{testNum: 4, err: notFound{}, code: ErrorNotFound, expected: true},
+		{testNum: 5, err: fmt.Errorf("wrapped: %w", notFound{}), code: ErrorNotFound, expected: true},
+		{testNum: 6, err: errors.New("resource is missing"), code: ErrorNotFound, expected: false},
+		{testNum: 7, err: list, code: ErrorNotFound, expected: true},
+		{testNum: 8, err: Conflictf("", "duplicate"), code: ErrorNotFound, expected: false},
+		{testNum: 9, err: nil, code: ErrorNotFound, expected: false},
+		{testNum: 10, err: mixed, code: ErrorNotFound, expected: true},
+		{testNum: 11, err: mixed, code: ErrorInternal, expected: true},
+		{testNum: 12, err: mixed, code: ErrorDuplicateEntry, expected: false},
+	}
+
+	for _, test := range tests {
+		if result := HasCode(test.err, test.code); result != test.expected || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%t\nGot.....:\n%t\n%s", test.testNum, test.expected, result, ErrorText(test.err))
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paulcarlton/go-utils/pkg/internal/common"
+)
+
+// contextKey is the type of the context keys used by core, it is unexported so the keys cannot collide
+// with keys defined by other packages
+type contextKey int
+
+const (
+	requestIDKey contextKey = iota
+	traceIDKey
+	userKey
+)
+
+// requestInfo identifies the request an error was created for
+type requestInfo struct {
+	requestID string
+	traceID   string
+	user      string
+}
+
+// WithRequestID returns a copy of a context holding a request id for MakeErrorCtx and RaiseErrorCtx
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// WithTraceID returns a copy of a context holding a trace id for MakeErrorCtx and RaiseErrorCtx
+func WithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDKey, traceID)
+}
+
+// WithUser returns a copy of a context holding the user making a request for MakeErrorCtx and RaiseErrorCtx
+func WithUser(ctx context.Context, user string) context.Context {
+	return context.WithValue(ctx, userKey, user)
+}
+
+// RequestIDFromContext returns the request id held in a context or an empty string
+func RequestIDFromContext(ctx context.Context) string {
+	return contextString(ctx, requestIDKey)
+}
+
+// TraceIDFromContext returns the trace id held in a context or an empty string
+func TraceIDFromContext(ctx context.Context) string {
+	return contextString(ctx, traceIDKey)
+}
+
+// UserFromContext returns the user held in a context or an empty string
+func UserFromContext(ctx context.Context) string {
+	return contextString(ctx, userKey)
+}
+
+// MakeErrorCtx creates a core.Error with the request id, trace id and user held in a context
+func MakeErrorCtx(ctx context.Context, id string, code int, msg string) error {
+	err := makeError(id, code, msg, common.GetCaller(4, true))
+	return addStack(withRequestInfo(err, requestInfoFromContext(ctx)), StackTracesEnabled())
+}
+
+// RaiseErrorCtx creates a core.Error from a nested error with the request id, trace id and user held in a
+// context. Identifiers that are not held in the context are inherited from a nested core.Error.
+func RaiseErrorCtx(ctx context.Context, id string, code int, msg string, nested interface{}) error {
+	err := raiseError(id, code, msg, common.GetCaller(4, true), nested)
+	return addStack(withRequestInfo(err, requestInfoFromContext(ctx)), StackTracesEnabled())
+}
+
+// RequestID returns the id of the request the error was created for or an empty string
+func (e *cerror) RequestID() string {
+	if e != nil {
+		return e.request.requestID
+	}
+	return ""
+}
+
+// TraceID returns the id of the trace the error was created in or an empty string
+func (e *cerror) TraceID() string {
+	if e != nil {
+		return e.request.traceID
+	}
+	return ""
+}
+
+// User returns the user making the request the error was created for or an empty string
+func (e *cerror) User() string {
+	if e != nil {
+		return e.request.user
+	}
+	return ""
+}
+
+// contextString returns a string value held in a context
+func contextString(ctx context.Context, key contextKey) string {
+	if ctx == nil {
+		return ""
+	}
+	value, _ := ctx.Value(key).(string)
+	return value
+}
+
+// requestInfoFromContext returns the identifiers held in a context
+func requestInfoFromContext(ctx context.Context) requestInfo {
+	return requestInfo{
+		requestID: RequestIDFromContext(ctx),
+		traceID:   TraceIDFromContext(ctx),
+		user:      UserFromContext(ctx),
+	}
+}
+
+// withRequestInfo sets the identifiers of a core.Error, identifiers that are empty are not changed
+func withRequestInfo(err error, info requestInfo) error {
+	if cerr, ok := err.(*cerror); ok {
+		cerr.request = cerr.request.merge(info)
+	}
+	return err
+}
+
+// merge returns the identifiers with those that are set in additional replacing them
+func (r requestInfo) merge(additional requestInfo) requestInfo {
+	if len(additional.requestID) > 0 {
+		r.requestID = additional.requestID
+	}
+	if len(additional.traceID) > 0 {
+		r.traceID = additional.traceID
+	}
+	if len(additional.user) > 0 {
+		r.user = additional.user
+	}
+	return r
+}
+
+// text returns the identifiers as lines of text for FullInfo
+func (r requestInfo) text() string {
+	text := ""
+	for _, line := range []struct{ label, value string }{
+		{label: "Request ID", value: r.requestID},
+		{label: "Trace ID", value: r.traceID},
+		{label: "User", value: r.user},
+	} {
+		if len(line.value) > 0 {
+			text = fmt.Sprintf("%s\n%s: %s", text, line.label, line.value)
+		}
+	}
+	return text
+}
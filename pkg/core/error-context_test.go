@@ -0,0 +1,127 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/paulcarlton/go-utils/pkg/testutils"
+)
+
+func TestContextIdentifiers(t *testing.T) {
+	ctx := WithUser(WithTraceID(WithRequestID(context.Background(), "req-1"), "trace-1"), "alice")
+	var tests = []struct {
+		testNum  int
+		ctx      context.Context
+		expected []string
+	}{
+		{testNum: 1, ctx: ctx, expected: []string{"req-1", "trace-1", "alice"}},
+		{testNum: 2, ctx: context.Background(), expected: []string{"", "", ""}},
+		{testNum: 3, ctx: nil, expected: []string{"", "", ""}},
+	}
+
+	for _, test := range tests {
+		result := []string{RequestIDFromContext(test.ctx), TraceIDFromContext(test.ctx), UserFromContext(test.ctx)}
+		if !compareStringArray(result, test.expected) || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%v\nGot.....:\n%v", test.testNum, test.expected, result)
+		}
+	}
+}
+
+func TestMakeErrorCtx(t *testing.T) {
+	ctx := WithUser(WithTraceID(WithRequestID(context.Background(), "req-1"), "trace-1"), "alice")
+	var tests = []struct {
+		testNum  int
+		ctx      context.Context
+		expected *cerror
+	}{
+		{
+			testNum: 1,
+			ctx:     ctx,
+			expected: &cerror{
+				code:               ErrorNotFound,
+				message:            "no such item",
+				id:                 "abc",
+				where:              "core.TestMakeErrorCtx() - error-context_test.go(NN)",
+				recommendedActions: []string{},
+				request:            requestInfo{requestID: "req-1", traceID: "trace-1", user: "alice"},
+			},
+		},
+		{
+			testNum: 2,
+			ctx:     context.Background(),
+			expected: &cerror{
+				code:               ErrorNotFound,
+				message:            "no such item",
+				id:                 "abc",
+				where:              "core.TestMakeErrorCtx() - error-context_test.go(NN)",
+				recommendedActions: []string{},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		result := MakeErrorCtx(test.ctx, "abc", ErrorNotFound, "no such item")
+		if !CompareErrors(result, test.expected) || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%s\nGot.....:\n%s\n%s", test.testNum, test.expected.FullInfo(), ErrorText(result),
+				test.expected.DiffText(result))
+		}
+	}
+}
+
+func TestRaiseErrorCtx(t *testing.T) {
+	nested := MakeErrorCtx(WithUser(WithRequestID(context.Background(), "req-0"), "bob"), "", ErrorNotFound, "no such item")
+	var tests = []struct {
+		testNum  int
+		ctx      context.Context
+		nested   interface{}
+		expected []string
+	}{
+		{testNum: 1, ctx: WithRequestID(context.Background(), "req-1"), nested: nested, expected: []string{"req-1", "", "bob"}},
+		{testNum: 2, ctx: context.Background(), nested: nested, expected: []string{"req-0", "", "bob"}},
+		{testNum: 3, ctx: WithTraceID(context.Background(), "trace-1"), nested: errors.New("failed"), expected: []string{"", "trace-1", ""}},
+	}
+
+	for _, test := range tests {
+		result := RaiseErrorCtx(test.ctx, "", ErrorInternal, "failed to get item", test.nested)
+		coreErr, ok := result.(Error)
+		if !ok || !compareStringArray([]string{coreErr.RequestID(), coreErr.TraceID(), coreErr.User()}, test.expected) ||
+			!compareWhere(coreErr.Where(), "core.TestRaiseErrorCtx() - error-context_test.go(NN)") || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%v\nGot.....:\n%s", test.testNum, test.expected, ErrorText(result))
+		}
+	}
+}
+
+func TestRequestInfoOutput(t *testing.T) {
+	ctx := WithTraceID(WithRequestID(context.Background(), "req-1"), "trace-1")
+	err := MakeErrorCtx(ctx, "abc", ErrorNotFound, "no such item")
+
+	fullInfo := err.(Error).FullInfo()
+	if !strings.Contains(fullInfo, "\nRequest ID: req-1\nTrace ID: trace-1") || strings.Contains(fullInfo, "User:") ||
+		testutils.FailTests {
+		t.Errorf("\nTest: 1\nExpected:\nRequest ID: req-1\nTrace ID: trace-1\nGot.....:\n%s", fullInfo)
+	}
+
+	data, e := json.Marshal(err)
+	if e != nil {
+		t.Fatalf("\nTest: 2\nFailed to marshal error: %s", e)
+	}
+	decoded, e := ErrorFromJSON(data)
+	if e != nil || !CompareErrors(decoded, err) || testutils.FailTests {
+		t.Errorf("\nTest: 2\nExpected:\n%s\nGot.....:\n%s\n%s", ErrorText(err), ErrorText(decoded), string(data))
+	}
+
+	recorder := httptest.NewRecorder()
+	if e := WriteHTTPError(recorder, err); e != nil {
+		t.Fatalf("\nTest: 3\nFailed to write error: %s", e)
+	}
+	expected := `"requestId":"req-1","traceId":"trace-1"`
+	if !strings.Contains(recorder.Body.String(), expected) || testutils.FailTests {
+		t.Errorf("\nTest: 3\nExpected:\n%s\nGot.....:\n%s", expected, recorder.Body.String())
+	}
+}
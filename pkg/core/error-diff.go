@@ -0,0 +1,223 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/paulcarlton/go-utils/pkg/internal/common"
+)
+
+// Difference is a field that differs between an expected and an actual error
+// Path identifies the field, for example "Nested[1].RecommendedActions[0]" is the first recommended action
+// of the second error in the nested chain and "Errors[0].Code" is the code of the first error in an ErrorList.
+// Expected and Actual hold the values compared, sensitive values are only redacted when a difference is formatted.
+type Difference struct {
+	Path     string
+	Expected interface{}
+	Actual   interface{}
+}
+
+// String returns a description of the difference with sensitive values redacted
+func (d Difference) String() string {
+	return fmt.Sprintf("%s: expected %s, got %s", d.Path, diffValue(d.Expected), diffValue(d.Actual))
+}
+
+// DiffErrors returns the differences between an expected and an actual error, comparing core.Error
+// values field by field and other errors by their text. Line numbers in Where and stack trace entries
+// are ignored if either value ends with "(NN)" and stack traces are only compared if both are recorded.
+// It returns an empty list if the errors match.
+func DiffErrors(expected, actual error) []Difference {
+	return diffErrors("", expected, actual)
+}
+
+// DiffText returns the differences between this error, as the expected error, and an actual error as text,
+// one difference per line. It returns an empty string if the errors match.
+func (e *cerror) DiffText(actual error) string {
+	return diffText(e, actual)
+}
+
+// DiffText returns the differences between this ErrorList, as the expected error, and an actual error as text
+func (l *ErrorList) DiffText(actual error) string {
+	return diffText(l, actual)
+}
+
+// diffText formats the differences between two errors
+func diffText(expected, actual error) string {
+	diffs := DiffErrors(expected, actual)
+	text := make([]string, 0, len(diffs))
+	for _, diff := range diffs {
+		text = append(text, diff.String())
+	}
+	return strings.Join(text, "\n")
+}
+
+// diffErrors compares two errors, prefixing the paths of the differences with path
+func diffErrors(path string, expected, actual error) []Difference {
+	if isNilError(expected) && isNilError(actual) {
+		return nil
+	} else if isNilError(expected) || isNilError(actual) {
+		return []Difference{{Path: diffPath(path, "Error"), Expected: errorValue(expected), Actual: errorValue(actual)}}
+	}
+
+	expectedCore, ok1 := expected.(Error)
+	actualCore, ok2 := actual.(Error)
+	if ok1 && ok2 {
+		return diffCoreErrors(path, expectedCore, actualCore)
+	}
+	if expected.Error() != actual.Error() {
+		return []Difference{{Path: diffPath(path, "Error"), Expected: expected.Error(), Actual: actual.Error()}}
+	}
+	return nil
+}
+
+// diffCoreErrors compares two core.Error values and their nested chains
+func diffCoreErrors(path string, expected, actual Error) []Difference {
+	diffs := []Difference{}
+	for depth := 0; expected != nil || actual != nil; depth++ {
+		prefix := path
+		if depth > 0 {
+			prefix = diffPath(path, fmt.Sprintf("Nested[%d]", depth-1))
+		}
+		nextExpected, nextActual := expected.Nested(), actual.Nested()
+		diffs = append(diffs, diffFields(prefix, expected, actual)...)
+
+		expected, actual = nil, nil
+		nestedPath := diffPath(path, fmt.Sprintf("Nested[%d]", depth))
+		if isNilError(nextExpected) || isNilError(nextActual) {
+			diffs = append(diffs, diffErrors(nestedPath, nextExpected, nextActual)...)
+			continue
+		}
+		// Nested errors must both be core.Error or both be other errors, which are compared by their text
+		expectedCore, ok1 := nextExpected.(Error)
+		actualCore, ok2 := nextActual.(Error)
+		switch {
+		case ok1 && ok2:
+			expected, actual = expectedCore, actualCore
+		case ok1 != ok2:
+			diffs = append(diffs, Difference{Path: diffPath(nestedPath, "Type"),
+				Expected: fmt.Sprintf("%T", nextExpected), Actual: fmt.Sprintf("%T", nextActual)})
+		default:
+			diffs = append(diffs, diffErrors(nestedPath, nextExpected, nextActual)...)
+		}
+	}
+	return diffs
+}
+
+// diffFields compares the fields of two core.Error values, ignoring their nested errors
+func diffFields(path string, expected, actual Error) []Difference {
+	diffs := []Difference{}
+	add := func(field string, one, two interface{}) {
+		if one != two {
+			diffs = append(diffs, Difference{Path: diffPath(path, field), Expected: one, Actual: two})
+		}
+	}
+
+	expectedList, ok1 := expected.(*ErrorList)
+	actualList, ok2 := actual.(*ErrorList)
+	if ok1 != ok2 {
+		add("Type", fmt.Sprintf("%T", expected), fmt.Sprintf("%T", actual))
+	} else if ok1 {
+		diffs = append(diffs, diffLists(path, expectedList, actualList)...)
+	}
+
+	add("Code", expected.Code(), actual.Code())
+	add("Message", expected.Message(), actual.Message())
+	add("ID", expected.ID(), actual.ID())
+	add("Reason", expected.Reason(), actual.Reason())
+	add("RequestID", expected.RequestID(), actual.RequestID())
+	add("TraceID", expected.TraceID(), actual.TraceID())
+	add("User", expected.User(), actual.User())
+	add("Retryable", expected.Retryable(), actual.Retryable())
+	add("Details", expected.Details(), actual.Details())
+	if !compareWhere(expected.Where(), actual.Where()) {
+		add("Where", expected.Where(), actual.Where())
+	}
+	diffs = append(diffs, diffStrings(diffPath(path, "RecommendedActions"), expected.RecommendedActions(), actual.RecommendedActions(), false)...)
+	if len(expected.StackTrace()) > 0 && len(actual.StackTrace()) > 0 {
+		diffs = append(diffs, diffStrings(diffPath(path, "StackTrace"), expected.StackTrace(), actual.StackTrace(), true)...)
+	}
+	diffs = append(diffs, diffStrings(diffPath(path, "Fields"), expected.Fields().rawText(), actual.Fields().rawText(), false)...)
+	return diffs
+}
+
+// diffLists compares the errors in two ErrorList values
+func diffLists(path string, expected, actual *ErrorList) []Difference {
+	diffs := []Difference{}
+	expectedErrors, actualErrors := expected.Errors(), actual.Errors()
+	if len(expectedErrors) != len(actualErrors) {
+		diffs = append(diffs, Difference{Path: diffPath(path, "Errors.Len"), Expected: len(expectedErrors), Actual: len(actualErrors)})
+	}
+	for index := 0; index < len(expectedErrors) && index < len(actualErrors); index++ {
+		diffs = append(diffs, diffErrors(diffPath(path, fmt.Sprintf("Errors[%d]", index)), expectedErrors[index], actualErrors[index])...)
+	}
+	return diffs
+}
+
+// diffStrings compares two lists of strings element by element, a nil list differs from an empty list
+func diffStrings(path string, expected, actual []string, where bool) []Difference {
+	if (expected == nil) != (actual == nil) || len(expected) != len(actual) {
+		return []Difference{{Path: path, Expected: expected, Actual: actual}}
+	}
+	diffs := []Difference{}
+	for index := range expected {
+		match := expected[index] == actual[index]
+		if where {
+			match = compareWhere(expected[index], actual[index])
+		}
+		if !match {
+			diffs = append(diffs, Difference{Path: fmt.Sprintf("%s[%d]", path, index), Expected: expected[index], Actual: actual[index]})
+		}
+	}
+	return diffs
+}
+
+// diffPath appends a field to a path
+func diffPath(path, field string) string {
+	if len(path) == 0 {
+		return field
+	}
+	return path + "." + field
+}
+
+// diffValue formats a value in a difference, redacting sensitive values in text
+func diffValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "nil"
+	case string:
+		return fmt.Sprintf("%q", common.RedactText(v))
+	case []string:
+		if v == nil {
+			return "nil"
+		}
+		redacted := make([]string, 0, len(v))
+		for _, text := range v {
+			redacted = append(redacted, common.RedactText(text))
+		}
+		return fmt.Sprintf("%q", redacted)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// errorValue returns the text of an error, or nil
+func errorValue(err error) interface{} {
+	if isNilError(err) {
+		return nil
+	}
+	return err.Error()
+}
+
+// isNilError checks if an error is nil or a nil core.Error
+func isNilError(err error) bool {
+	switch e := err.(type) {
+	case nil:
+		return true
+	case *cerror:
+		return e == nil
+	case *ErrorList:
+		return e == nil
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package core
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/paulcarlton/go-utils/pkg/testutils"
+)
+
+func TestDiffErrors(t *testing.T) {
+	expected := &cerror{
+		code:               ErrorNotFound,
+		message:            msg,
+		where:              "core.TestDiffErrors() - error-diff_test.go(NN)",
+		recommendedActions: []string{},
+		nestedError: &cerror{
+			code:               ErrorInternal,
+			message:            "nested",
+			recommendedActions: []string{},
+			nestedError:        &cerror{code: ErrorInternal, recommendedActions: []string{"retry"}, nestedError: errors.New("root")},
+		},
+	}
+	actual := &cerror{
+		code:               ErrorNotFound,
+		message:            msg,
+		where:              "core.TestDiffErrors() - error-diff_test.go(30)",
+		recommendedActions: []string{},
+		nestedError: &cerror{
+			code:               ErrorInternal,
+			message:            "nested",
+			recommendedActions: []string{},
+			nestedError:        &cerror{code: ErrorInternal, recommendedActions: []string{"restart"}, nestedError: errors.New("cause")},
+		},
+	}
+	expectedList := newErrorList("list", msg, "not available")
+	expectedList.Append(&cerror{code: ErrorNotFound, id: "a"}, errors.New("other"))
+	actualList := newErrorList("list", msg, "not available")
+	actualList.Append(&cerror{code: ErrorNotFound, id: "b"})
+
+	var tests = []struct {
+		testNum  int
+		expected error
+		actual   error
+		diffs    []string
+	}{
+		{testNum: 1, expected: expected, actual: expected, diffs: []string{}},
+		{testNum: 2, expected: expected, actual: actual, diffs: []string{
+			`Nested[1].RecommendedActions[0]: expected "retry", got "restart"`,
+			`Nested[2].Error: expected "root", got "cause"`,
+		}},
+		{testNum: 3, expected: &cerror{message: "a", where: "x.go(NN)", fields: Fields{"uri": "a"}},
+			actual: &cerror{message: "b", where: "y.go(12)", recommendedActions: []string{}, fields: Fields{"uri": "b"}}, diffs: []string{
+				`Message: expected "a", got "b"`,
+				`Where: expected "x.go(NN)", got "y.go(12)"`,
+				`RecommendedActions: expected nil, got []`,
+				`Fields[0]: expected "uri: a", got "uri: b"`,
+			}},
+		{testNum: 4, expected: expectedList, actual: actualList, diffs: []string{
+			"Errors.Len: expected 2, got 1",
+			`Errors[0].ID: expected "a", got "b"`,
+		}},
+		{testNum: 5, expected: &cerror{nestedError: &cerror{}}, actual: &cerror{nestedError: errors.New("std")},
+			diffs: []string{`Nested[0].Type: expected "*core.cerror", got "*errors.errorString"`}},
+		{testNum: 6, expected: nil, actual: errors.New("std"), diffs: []string{`Error: expected nil, got "std"`}},
+		{testNum: 7, expected: &cerror{message: "a"}, actual: errors.New("  a"), diffs: []string{}},
+		{testNum: 8, expected: &cerror{fields: Fields{"token": "secret1"}}, actual: &cerror{fields: Fields{"token": "secret2"}},
+			diffs: []string{`Fields[0]: expected "token: [REDACTED]", got "token: [REDACTED]"`}},
+	}
+
+	for _, test := range tests {
+		result := []string{}
+		for _, diff := range DiffErrors(test.expected, test.actual) {
+			result = append(result, diff.String())
+		}
+		if !compareStringArray(result, test.diffs) || CompareErrors(test.expected, test.actual) != (len(test.diffs) == 0) || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%s\nGot.....:\n%s", test.testNum, strings.Join(test.diffs, "\n"), strings.Join(result, "\n"))
+		}
+	}
+
+	if expected.DiffText(actual) != `Nested[1].RecommendedActions[0]: expected "retry", got "restart"`+"\n"+
+		`Nested[2].Error: expected "root", got "cause"` || testutils.FailTests {
+		t.Errorf("\nTest: 9\nGot.....:\n%s", expected.DiffText(actual))
+	}
+}
@@ -0,0 +1,93 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package core
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/paulcarlton/go-utils/pkg/internal/common"
+)
+
+// WithField returns a copy of the core.Error with the field added
+func (e *cerror) WithField(key string, value interface{}) Error {
+	return e.WithFields(Fields{key: value})
+}
+
+// WithFields returns a copy of the core.Error with the fields added, replacing existing fields with the same key
+func (e *cerror) WithFields(fields Fields) Error {
+	return e.with(func(result *cerror) {
+		result.fields = e.fields.merge(fields)
+	})
+}
+
+// Fields returns a copy of the key/value context fields of a core.Error
+func (e *cerror) Fields() Fields {
+	if e != nil {
+		return e.fields.merge(nil)
+	}
+	return nil
+}
+
+// WithFields returns a copy of err with the fields added if it is a core.Error, otherwise err is returned unchanged
+func WithFields(err error, fields Fields) error {
+	if coreErr, ok := err.(Error); ok && coreErr != nil {
+		return coreErr.WithFields(fields)
+	}
+	return err
+}
+
+// merge returns a new Fields containing the fields and the additional fields, or nil if both are empty
+func (f Fields) merge(additional Fields) Fields {
+	if len(f) == 0 && len(additional) == 0 {
+		return nil
+	}
+	result := make(Fields, len(f)+len(additional))
+	for key, value := range f {
+		result[key] = value
+	}
+	for key, value := range additional {
+		result[key] = value
+	}
+	return result
+}
+
+// keys returns the field keys in sorted order
+func (f Fields) keys() []string {
+	keys := make([]string, 0, len(f))
+	for key := range f {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// text returns the fields as 'key: value' strings sorted by key, with the values of sensitive keys redacted
+func (f Fields) text() []string {
+	text := make([]string, 0, len(f))
+	for _, key := range f.keys() {
+		text = append(text, fmt.Sprintf("%s: %v", key, common.RedactValue(key, f[key])))
+	}
+	return text
+}
+
+// rawText returns the fields as 'key: value' strings sorted by key, without redaction
+func (f Fields) rawText() []string {
+	text := make([]string, 0, len(f))
+	for _, key := range f.keys() {
+		text = append(text, fmt.Sprintf("%s: %v", key, f[key]))
+	}
+	return text
+}
+
+// redacted returns a copy of the fields with the values of sensitive keys redacted
+func (f Fields) redacted() Fields {
+	if len(f) == 0 {
+		return f
+	}
+	result := make(Fields, len(f))
+	for key, value := range f {
+		result[key] = common.RedactValue(key, value)
+	}
+	return result
+}
@@ -0,0 +1,132 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/paulcarlton/go-utils/pkg/internal/common"
+	"github.com/paulcarlton/go-utils/pkg/testutils"
+)
+
+func TestErrorWithFields(t *testing.T) {
+	original := &cerror{
+		id:      "test1",
+		where:   "not available",
+		code:    ErrorBadRequest,
+		message: msg,
+		fields:  Fields{"namespace": "default"},
+	}
+	var tests = []struct {
+		testNum  int
+		result   Error
+		expected Fields
+	}{
+		{testNum: 1, result: original.WithField("attempt", 2), expected: Fields{"namespace": "default", "attempt": 2}},
+		{testNum: 2, result: original.WithFields(Fields{"namespace": "test", "uri": "memory:///a"}),
+			expected: Fields{"namespace": "test", "uri": "memory:///a"}},
+		{testNum: 3, result: original, expected: Fields{"namespace": "default"}},
+		{testNum: 4, result: (&cerror{}).WithFields(nil), expected: nil},
+	}
+
+	for _, test := range tests {
+		result := test.result.Fields()
+		if !compareStringArray(result.text(), test.expected.text()) || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%v\nGot.....:\n%v", test.testNum, test.expected, result)
+		}
+	}
+
+	var nilErr *cerror
+	if nilErr.WithField("key", "value") != nil || nilErr.Fields() != nil || testutils.FailTests {
+		t.Errorf("\nTest: 5\nExpected nil for nil core.Error")
+	}
+}
+
+func TestWithFields(t *testing.T) {
+	stdErr := fmt.Errorf("%s", "std error")
+	if result := WithFields(stdErr, Fields{"key": "value"}); result != stdErr || testutils.FailTests {
+		t.Errorf("\nTest: 1\nExpected:\n%v\nGot.....:\n%v", stdErr, result)
+	}
+
+	result := WithFields(MakeError("test1", ErrorBadRequest, msg), Fields{"key": "value"})
+	if result.(Error).Fields()["key"] != "value" || testutils.FailTests {
+		t.Errorf("\nTest: 2\nExpected field in:\n%s", ErrorText(result))
+	}
+}
+
+func TestRaiseErrorInheritsFields(t *testing.T) {
+	nested := WithFields(MakeError("test1", ErrorNotFound, "item not found"), Fields{"uri": "memory:///a", "attempt": 1})
+	result := WithFields(RaiseError("test2", ErrorUnknown, "failed to get item", nested), Fields{"attempt": 2})
+	expected := Fields{"uri": "memory:///a", "attempt": 2}
+	if !compareStringArray(result.(Error).Fields().text(), expected.text()) || testutils.FailTests {
+		t.Errorf("\nTest: 1\nExpected:\n%v\nGot.....:\n%v", expected, result.(Error).Fields())
+	}
+	if nested.(Error).Fields()["attempt"] != 1 || testutils.FailTests {
+		t.Errorf("\nTest: 2\nExpected nested fields to be unchanged, got:\n%v", nested.(Error).Fields())
+	}
+}
+
+func TestErrorFieldsFullInfo(t *testing.T) {
+	coreErr := &cerror{
+		id:                 "test1",
+		where:              "not available",
+		code:               ErrorBadRequest,
+		message:            msg,
+		recommendedActions: []string{"action1"},
+		fields:             Fields{"uri": "memory:///a", "attempt": 2},
+	}
+	expected := fmt.Sprintf("not available test1 %s %s\nRecommended actions...\naction1\nFields...\nattempt: 2\nuri: memory:///a",
+		CodeText(ErrorBadRequest), msg)
+	if result := ErrorText(coreErr); result != expected || testutils.FailTests {
+		t.Errorf("\nTest: 1\nExpected:\n%s\nGot.....:\n%s", expected, result)
+	}
+
+	data, err := json.Marshal(coreErr)
+	if err != nil {
+		t.Fatalf("failed to marshal: %s", err)
+	}
+	result, err := ErrorFromJSON(data)
+	if err != nil || !CompareErrors(result, coreErr) || testutils.FailTests {
+		t.Errorf("\nTest: 2\nJSON....:\n%s\nExpected:\n%s\nGot.....:\n%s", data, coreErr.FullInfo(), ErrorText(result))
+	}
+
+	if CompareErrors(coreErr.WithField("attempt", 3), coreErr) || testutils.FailTests {
+		t.Errorf("\nTest: 3\nExpected errors with different fields to differ")
+	}
+}
+
+func TestErrorRedaction(t *testing.T) {
+	coreErr := &cerror{
+		id:      "test1",
+		where:   "not available",
+		code:    ErrorUnauthorized,
+		message: "login failed, password=secret1",
+		fields:  Fields{"token": "secret2", "user": "admin"},
+	}
+	expected := fmt.Sprintf("not available test1 %s login failed, password=[REDACTED]\nFields...\ntoken: [REDACTED]\nuser: admin",
+		CodeText(ErrorUnauthorized))
+	if coreErr.FullInfo() != expected || testutils.FailTests {
+		t.Errorf("\nTest: 1\nExpected:\n%s\nGot.....:\n%s", expected, coreErr.FullInfo())
+	}
+
+	data, err := json.Marshal(coreErr)
+	if err != nil || strings.Contains(string(data), "secret") || testutils.FailTests {
+		t.Errorf("\nTest: 2\nExpected no secrets in:\n%s", data)
+	}
+
+	raw := &cerror{id: "test1", where: "not available", code: ErrorServiceUnavailable, message: "token: connection refused"}
+	expected = fmt.Sprintf("not available test1 %s token: connection refused", CodeText(ErrorServiceUnavailable))
+	if coreErr.Error() != fmt.Sprintf("not available test1 %s login failed, password=secret1", CodeText(ErrorUnauthorized)) ||
+		raw.Error() != expected || testutils.FailTests {
+		t.Errorf("\nTest: 3\nExpected:\n%s\nGot.....:\n%s", expected, raw.Error())
+	}
+
+	common.EnableRedaction(false)
+	defer common.EnableRedaction(true)
+	if !strings.Contains(coreErr.FullInfo(), "secret2") || testutils.FailTests {
+		t.Errorf("\nTest: 4\nExpected secrets when redaction disabled in:\n%s", coreErr.FullInfo())
+	}
+}
@@ -0,0 +1,187 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// whereLine matches the line number at the end of a where string
+	whereLine = regexp.MustCompile(`\(\d+\)$`)
+
+	// messageMasks replace the variable parts of a message, in order, to give a message template
+	messageMasks = []struct {
+		pattern     *regexp.Regexp
+		replacement string
+	}{
+		{pattern: regexp.MustCompile("\"[^\"]*\"|'[^']*'|`[^`]*`"), replacement: "<str>"},
+		{pattern: regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://[^\s,;]*`), replacement: "<uri>"},
+		{pattern: regexp.MustCompile(`\b[a-zA-Z0-9][a-zA-Z0-9.-]*:\d{1,5}\b`), replacement: "<host>"},
+		{pattern: regexp.MustCompile(`\b\d{1,3}(\.\d{1,3}){3}\b`), replacement: "<host>"},
+		{pattern: regexp.MustCompile(`(^|[\s(=\[])((~|\.{1,2})?/[^\s,;:()'"]+|[^\s/,;:()'"]+(/[^\s/,;:()'"]+)+)`),
+			replacement: "${1}<path>"},
+		{pattern: regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`), replacement: "<uuid>"},
+		{pattern: regexp.MustCompile(`(?i)\b(0x)?[0-9a-f]*(\d[0-9a-f]*[a-f]|[a-f][0-9a-f]*\d)[0-9a-f]*\b`), replacement: "<hex>"},
+		{pattern: regexp.MustCompile(`\b\d+(\.\d+)?([a-zA-Z]{1,2})?\b`), replacement: "<n>"},
+	}
+)
+
+// DefaultAggregatorLimit is the maximum number of fingerprints an Aggregator created by NewAggregator records
+const DefaultAggregatorLimit = 1000
+
+type (
+	// Occurrence records how often errors with the same fingerprint were seen
+	Occurrence struct {
+		Fingerprint string
+		Count       uint64
+		FirstSeen   time.Time
+		LastSeen    time.Time
+		Last        error // Last is the most recent error with this fingerprint
+	}
+
+	// Aggregator counts the occurrences of errors by fingerprint, it is safe for concurrent use
+	// When the limit on the number of fingerprints is reached the least recently seen occurrence is removed.
+	Aggregator struct {
+		lock        sync.Mutex
+		occurrences map[string]*Occurrence
+		limit       int
+		now         func() time.Time
+	}
+)
+
+// Fingerprint returns a stable hash identifying a kind of failure so occurrences of the same failure can be
+// grouped. It is built from the code, reason, Where without the line number and the message with variable
+// parts such as numbers, quoted strings and uris masked, and the fingerprints of the nested errors. The id,
+// details, fields and stack trace are not used. It returns an empty string for a nil error.
+func Fingerprint(err error) string {
+	if err == nil {
+		return ""
+	}
+	hash := sha256.Sum256([]byte(fingerprintText(err)))
+	return hex.EncodeToString(hash[:])
+}
+
+// fingerprintText returns the normalized text an error's fingerprint is built from
+func fingerprintText(err error) string {
+	switch e := err.(type) {
+	case *ErrorList:
+		errs := e.Errors()
+		members := make([]string, 0, len(errs))
+		for _, member := range errs {
+			members = append(members, Fingerprint(member))
+		}
+		sort.Strings(members)
+		return fmt.Sprintf("%s\nerrors: %s", fingerprintText(e.header()), strings.Join(members, ","))
+	case Error:
+		return fmt.Sprintf("code: %d\nreason: %s\nwhere: %s\nmessage: %s\nnested: %s",
+			e.Code(), e.Reason(), NormalizeWhere(e.Where()), MaskMessage(e.Message()), Fingerprint(e.Nested()))
+	}
+	return fmt.Sprintf("message: %s\nnested: %s", MaskMessage(err.Error()), Fingerprint(nestedError(err)))
+}
+
+// NormalizeWhere replaces the line number at the end of a where string with "(NN)"
+func NormalizeWhere(where string) string {
+	return whereLine.ReplaceAllString(where, "(NN)")
+}
+
+// MaskMessage replaces the variable parts of a message, such as numbers, hex strings, uuids, quoted strings,
+// uris, host:port addresses and file or object paths, with placeholders so messages that only differ in these
+// parts are the same
+func MaskMessage(message string) string {
+	for _, mask := range messageMasks {
+		message = mask.pattern.ReplaceAllString(message, mask.replacement)
+	}
+	return message
+}
+
+// NewAggregator creates an empty Aggregator that records up to DefaultAggregatorLimit fingerprints
+func NewAggregator() *Aggregator {
+	return NewAggregatorLimit(DefaultAggregatorLimit)
+}
+
+// NewAggregatorLimit creates an empty Aggregator that records up to limit fingerprints, a limit of zero
+// or less uses DefaultAggregatorLimit
+func NewAggregatorLimit(limit int) *Aggregator {
+	if limit <= 0 {
+		limit = DefaultAggregatorLimit
+	}
+	return &Aggregator{occurrences: map[string]*Occurrence{}, limit: limit, now: time.Now}
+}
+
+// Add records an occurrence of an error and returns its fingerprint, nil errors are ignored
+func (a *Aggregator) Add(err error) string {
+	if err == nil {
+		return ""
+	}
+	fingerprint := Fingerprint(err)
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	now := a.now()
+	occurrence, ok := a.occurrences[fingerprint]
+	if !ok {
+		if len(a.occurrences) >= a.limit {
+			a.evict()
+		}
+		occurrence = &Occurrence{Fingerprint: fingerprint, FirstSeen: now}
+		a.occurrences[fingerprint] = occurrence
+	}
+	occurrence.Count++
+	occurrence.LastSeen = now
+	occurrence.Last = err
+	return fingerprint
+}
+
+// evict removes the least recently seen occurrence record
+func (a *Aggregator) evict() {
+	oldest := ""
+	for fingerprint, occurrence := range a.occurrences {
+		if len(oldest) == 0 || occurrence.LastSeen.Before(a.occurrences[oldest].LastSeen) {
+			oldest = fingerprint
+		}
+	}
+	delete(a.occurrences, oldest)
+}
+
+// Get returns the occurrence record for a fingerprint
+func (a *Aggregator) Get(fingerprint string) (Occurrence, bool) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	occurrence, ok := a.occurrences[fingerprint]
+	if !ok {
+		return Occurrence{}, false
+	}
+	return *occurrence, true
+}
+
+// Occurrences returns the occurrence records, most frequent first
+func (a *Aggregator) Occurrences() []Occurrence {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	result := make([]Occurrence, 0, len(a.occurrences))
+	for _, occurrence := range a.occurrences {
+		result = append(result, *occurrence)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Fingerprint < result[j].Fingerprint
+	})
+	return result
+}
+
+// Reset removes all occurrence records
+func (a *Aggregator) Reset() {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.occurrences = map[string]*Occurrence{}
+}
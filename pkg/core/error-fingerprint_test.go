@@ -0,0 +1,131 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/paulcarlton/go-utils/pkg/testutils"
+)
+
+func TestMaskMessage(t *testing.T) {
+	var tests = []struct {
+		testNum  int
+		message  string
+		expected string
+	}{
+		{testNum: 1, message: "retry 3 of 5 failed after 1.5 seconds", expected: "retry <n> of <n> failed after <n> seconds"},
+		{testNum: 2, message: `secret "db-password" not found`, expected: "secret <str> not found"},
+		{testNum: 3, message: "failed to get memory:///secret/a/b, not found", expected: "failed to get <uri>, not found"},
+		{testNum: 4, message: "object 123e4567-e89b-12d3-a456-426614174000 commit 9f86d081 missing", expected: "object <uuid> commit <hex> missing"},
+		{testNum: 5, message: "k8s api unavailable for 30s", expected: "k8s api unavailable for <n>"},
+		{testNum: 6, message: "dial tcp 10.0.0.1:443: connection refused", expected: "dial tcp <host>: connection refused"},
+		{testNum: 7, message: "connect to db-1.example.com:5432 from 192.168.1.10 failed", expected: "connect to <host> from <host> failed"},
+		{testNum: 8, message: "open /etc/certs/tls.key: no such file", expected: "open <path>: no such file"},
+		{testNum: 9, message: "secret team-a/db-creds not found (../config/app.yaml)", expected: "secret <path> not found (<path>)"},
+		{testNum: 10, message: "object `bucket-7` is locked", expected: "object <str> is locked"},
+	}
+
+	for _, test := range tests {
+		result := MaskMessage(test.message)
+		if result != test.expected || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%s\nGot.....:\n%s", test.testNum, test.expected, result)
+		}
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	base := &cerror{id: "secret1", code: ErrorNotFound, where: "core.Func() - file.go(10)", message: `secret "a" not found`,
+		nestedError: errors.New("attempt 1 failed")}
+	list := newErrorList("list", msg, "core.Func() - file.go(10)")
+	list.Append(base, errors.New("other"))
+	reversed := newErrorList("list", msg, "core.Func() - file.go(12)")
+	reversed.Append(errors.New("other"), base)
+
+	var tests = []struct {
+		testNum int
+		one     error
+		two     error
+		same    bool
+	}{
+		{testNum: 1, one: base, two: &cerror{id: "secret2", code: ErrorNotFound, where: "core.Func() - file.go(22)",
+			message: `secret "b" not found`, details: "other details", nestedError: errors.New("attempt 2 failed")}, same: true},
+		{testNum: 2, one: base, two: &cerror{id: "secret1", code: ErrorInternal, where: base.where, message: base.message,
+			nestedError: base.nestedError}, same: false},
+		{testNum: 3, one: base, two: &cerror{id: "secret1", code: ErrorNotFound, where: "core.Other() - file.go(10)",
+			message: base.message, nestedError: base.nestedError}, same: false},
+		{testNum: 4, one: base, two: &cerror{id: "secret1", code: ErrorNotFound, where: base.where, message: base.message,
+			nestedError: errors.New("connection refused")}, same: false},
+		{testNum: 5, one: base, two: base.WithFields(Fields{"attempt": 2}), same: true},
+		{testNum: 6, one: list, two: reversed, same: true},
+		{testNum: 7, one: errors.New("timeout after 30s"), two: errors.New("timeout after 10ms"), same: true},
+		{testNum: 8, one: errors.New("timeout"), two: errors.New("refused"), same: false},
+		{testNum: 9, one: errors.New("dial tcp 10.0.0.1:443: connection refused"),
+			two: errors.New("dial tcp 10.0.0.2:8443: connection refused"), same: true},
+		{testNum: 10, one: errors.New("open /var/data/a.db: permission denied"),
+			two: errors.New("open /var/data/b.db: permission denied"), same: true},
+	}
+
+	for _, test := range tests {
+		one, two := Fingerprint(test.one), Fingerprint(test.two)
+		if (one == two) != test.same || len(one) != 64 || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected same: %t\nGot.....:\n%s\n%s", test.testNum, test.same, one, two)
+		}
+	}
+
+	if Fingerprint(nil) != "" || testutils.FailTests {
+		t.Errorf("\nTest: 11\nExpected:\nempty fingerprint for nil\nGot.....:\n%s", Fingerprint(nil))
+	}
+}
+
+func TestAggregator(t *testing.T) {
+	aggregator := NewAggregator()
+	clock := time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC)
+	aggregator.now = func() time.Time {
+		clock = clock.Add(time.Second)
+		return clock
+	}
+
+	notFound := func(name string) error {
+		return &cerror{id: name, code: ErrorNotFound, where: "core.Func() - file.go(10)", message: "secret " + name + " not found"}
+	}
+	first := aggregator.Add(notFound("'a'"))
+	aggregator.Add(notFound("'b'"))
+	other := aggregator.Add(errors.New("failed"))
+	aggregator.Add(nil)
+	last := notFound("'c'")
+	aggregator.Add(last)
+
+	occurrence, ok := aggregator.Get(first)
+	expectedFirst := time.Date(2019, 1, 2, 3, 4, 6, 0, time.UTC)
+	expectedLast := time.Date(2019, 1, 2, 3, 4, 9, 0, time.UTC)
+	if !ok || occurrence.Count != 3 || !occurrence.FirstSeen.Equal(expectedFirst) || !occurrence.LastSeen.Equal(expectedLast) ||
+		occurrence.Last != last || testutils.FailTests {
+		t.Errorf("\nTest: 1\nExpected:\n3 occurrences from %s to %s\nGot.....:\n%+v", expectedFirst, expectedLast, occurrence)
+	}
+
+	occurrences := aggregator.Occurrences()
+	if len(occurrences) != 2 || occurrences[0].Fingerprint != first || occurrences[1].Fingerprint != other || testutils.FailTests {
+		t.Errorf("\nTest: 2\nExpected:\n2 fingerprints, most frequent first\nGot.....:\n%+v", occurrences)
+	}
+
+	aggregator.Reset()
+	if _, ok := aggregator.Get(first); ok || len(aggregator.Occurrences()) != 0 || testutils.FailTests {
+		t.Errorf("\nTest: 3\nExpected:\nno occurrences after reset")
+	}
+
+	limited := NewAggregatorLimit(2)
+	limited.now = aggregator.now
+	oldest := limited.Add(errors.New("timeout"))
+	recent := limited.Add(errors.New("refused"))
+	limited.Add(errors.New("timeout"))
+	limited.Add(errors.New("reset"))
+	if _, ok := limited.Get(recent); ok || len(limited.Occurrences()) != 2 || testutils.FailTests {
+		t.Errorf("\nTest: 4\nExpected:\nleast recently seen occurrence removed\nGot.....:\n%+v", limited.Occurrences())
+	}
+	if occurrence, ok := limited.Get(oldest); !ok || occurrence.Count != 2 || testutils.FailTests {
+		t.Errorf("\nTest: 5\nExpected:\n2 occurrences\nGot.....:\n%+v", occurrence)
+	}
+}
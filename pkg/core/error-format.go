@@ -0,0 +1,39 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package core
+
+import (
+	"fmt"
+	"io"
+)
+
+// Format implements fmt.Formatter so core.Error can be printed with the verbs:
+// %s and %v print the short form returned by Error()
+// %+v prints the full details returned by FullInfo(), including the stack trace if one was captured
+// %q prints the short form as a double quoted string
+func (e *cerror) Format(s fmt.State, verb rune) {
+	format(e, s, verb)
+}
+
+// Format implements fmt.Formatter for ErrorList, using the same verbs as core.Error
+func (l *ErrorList) Format(s fmt.State, verb rune) {
+	format(l, s, verb)
+}
+
+// format writes a core.Error for a verb
+func format(err Error, s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			_, _ = io.WriteString(s, err.FullInfo())
+			return
+		}
+		_, _ = io.WriteString(s, err.Error())
+	case 's':
+		_, _ = io.WriteString(s, err.Error())
+	case 'q':
+		_, _ = fmt.Fprintf(s, "%q", err.Error())
+	default:
+		_, _ = fmt.Fprintf(s, "%%!%c(%T=%s)", verb, err, err.Error())
+	}
+}
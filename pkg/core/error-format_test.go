@@ -0,0 +1,51 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/paulcarlton/go-utils/pkg/testutils"
+)
+
+func TestFormat(t *testing.T) {
+	coreErr := &cerror{
+		id:                 "test1",
+		where:              "not available",
+		code:               ErrorNotFound,
+		message:            msg,
+		recommendedActions: []string{"retry"},
+		stack:              []string{"core.TestFormat() - error-format_test.go(14)"},
+		nestedError:        errors.New("nested error"),
+	}
+	list := newErrorList("list1", msg, "not available")
+	list.Append(coreErr, errors.New("other error"))
+	var nilErr *cerror
+
+	var tests = []struct {
+		testNum  int
+		format   string
+		err      error
+		expected string
+	}{
+		{testNum: 1, format: "%s", err: coreErr, expected: coreErr.Error()},
+		{testNum: 2, format: "%v", err: coreErr, expected: coreErr.Error()},
+		{testNum: 3, format: "%+v", err: coreErr, expected: coreErr.FullInfo()},
+		{testNum: 4, format: "%q", err: coreErr, expected: fmt.Sprintf("%q", coreErr.Error())},
+		{testNum: 5, format: "%d", err: coreErr, expected: fmt.Sprintf("%%!d(*core.cerror=%s)", coreErr.Error())},
+		{testNum: 6, format: "%v", err: list, expected: list.Error()},
+		{testNum: 7, format: "%+v", err: list, expected: list.FullInfo()},
+		{testNum: 8, format: "%q", err: list, expected: fmt.Sprintf("%q", list.Error())},
+		{testNum: 9, format: "%v", err: nilErr, expected: ""},
+		{testNum: 10, format: "failed: %v", err: fmt.Errorf("wrapped: %w", coreErr), expected: "failed: wrapped: " + coreErr.Error()},
+	}
+
+	for _, test := range tests {
+		result := fmt.Sprintf(test.format, test.err)
+		if result != test.expected || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%s\nGot.....:\n%s", test.testNum, test.expected, result)
+		}
+	}
+}
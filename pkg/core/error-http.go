@@ -0,0 +1,160 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/paulcarlton/go-utils/pkg/internal/common"
+)
+
+const (
+	// ProblemContentType is the content type of a RFC 7807 problem details response
+	ProblemContentType = "application/problem+json"
+	// ProblemTypeDefault is the problem type used when no further semantics are defined
+	ProblemTypeDefault = "about:blank"
+)
+
+// maxResponseBody is the maximum number of bytes of a response body read by ErrorFromHTTPResponse
+const maxResponseBody = 1 << 20
+
+// problem is a RFC 7807 problem details object with core.Error specific extension members
+type problem struct {
+	Type               string   `json:"type"`
+	Title              string   `json:"title"`
+	Status             int      `json:"status"`
+	Detail             string   `json:"detail,omitempty"`
+	Code               int      `json:"code,omitempty"`
+	Reason             string   `json:"reason,omitempty"`
+	RequestID          string   `json:"requestId,omitempty"`
+	TraceID            string   `json:"traceId,omitempty"`
+	User               string   `json:"user,omitempty"`
+	ID                 string   `json:"id,omitempty"`
+	Message            string   `json:"message,omitempty"`
+	RecommendedActions []string `json:"recommendedActions,omitempty"`
+}
+
+// HTTPStatus returns the http status to report for a core error code
+// Codes that are not http error statuses, such as ErrorUnknown, are reported as an internal server error
+func HTTPStatus(code int) int {
+	if code >= http.StatusBadRequest && code < 600 && len(http.StatusText(code)) > 0 {
+		return code
+	}
+	return http.StatusInternalServerError
+}
+
+// WriteHTTPError writes an error to a http response as an application/problem+json document
+// An error that is not a core.Error is reported as an ErrorInternal. The title is the text of the status written.
+func WriteHTTPError(w http.ResponseWriter, err error) error {
+	if err == nil {
+		return fmt.Errorf(nilErrorObjectPassed)
+	}
+
+	coreErr, ok := err.(Error)
+	if !ok {
+		coreErr = &cerror{code: ErrorInternal, message: err.Error()}
+	}
+
+	status := HTTPStatus(coreErr.Code())
+	p := &problem{
+		Type:               ProblemTypeDefault,
+		Title:              http.StatusText(status),
+		Status:             status,
+		Detail:             common.RedactText(coreErr.Details()),
+		Code:               coreErr.Code(),
+		Reason:             coreErr.Reason(),
+		RequestID:          coreErr.RequestID(),
+		TraceID:            coreErr.TraceID(),
+		User:               coreErr.User(),
+		ID:                 coreErr.ID(),
+		Message:            common.RedactText(coreErr.Message()),
+		RecommendedActions: coreErr.RecommendedActions(),
+	}
+
+	data, e := json.Marshal(p)
+	if e != nil {
+		return RaiseError(coreErr.ID(), ErrorInternal, "failed to marshal problem details", e)
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	if _, e := w.Write(data); e != nil {
+		return RaiseError(coreErr.ID(), ErrorInternal, "failed to write problem details", e)
+	}
+	return nil
+}
+
+// ErrorFromHTTPResponse creates a core.Error from a http response with an error status
+// It returns nil if the response status is not an error status. If the response is an
+// application/problem+json document the core.Error is created from it, otherwise the
+// response status and body text are used. No more than 1MB of the body is read.
+func ErrorFromHTTPResponse(resp *http.Response) error {
+	where := common.GetCaller(4, true)
+	if resp == nil {
+		return fmt.Errorf(nilErrorObjectPassed)
+	}
+	if resp.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if err != nil {
+		return raiseError("", resp.StatusCode, "failed to read response body", where, err)
+	}
+
+	if isProblem(resp.Header.Get("Content-Type")) {
+		p := &problem{}
+		if err := json.Unmarshal(body, p); err == nil && p.Status > 0 {
+			return fromProblem(p, where)
+		}
+	}
+
+	message := strings.TrimSpace(string(body))
+	if len(message) == 0 {
+		message = resp.Status
+	}
+	return makeError("", resp.StatusCode, message, where)
+}
+
+// isProblem checks if a content type is json that may hold problem details
+func isProblem(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == ProblemContentType || mediaType == "application/json"
+}
+
+// fromProblem creates a core.Error from problem details
+func fromProblem(p *problem, where string) error {
+	code := p.Code
+	if code == 0 {
+		code = p.Status
+	}
+	message := p.Message
+	if len(message) == 0 {
+		message = p.Title
+	}
+
+	result := &cerror{
+		code:               code,
+		reason:             p.Reason,
+		request:            requestInfo{requestID: p.RequestID, traceID: p.TraceID, user: p.User},
+		id:                 p.ID,
+		message:            message,
+		details:            p.Detail,
+		where:              where,
+		recommendedActions: p.RecommendedActions,
+	}
+	if result.recommendedActions == nil {
+		result.recommendedActions = []string{}
+	}
+	return result
+}
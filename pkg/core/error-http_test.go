@@ -0,0 +1,177 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package core
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/paulcarlton/go-utils/pkg/testutils"
+)
+
+func TestHTTPStatus(t *testing.T) {
+	var tests = []struct {
+		testNum  int
+		code     int
+		expected int
+	}{
+		{testNum: 1, code: ErrorNotFound, expected: http.StatusNotFound},
+		{testNum: 2, code: ErrorUnknown, expected: http.StatusInternalServerError},
+		{testNum: 3, code: ErrorServiceUnavailable, expected: http.StatusServiceUnavailable},
+		{testNum: 4, code: http.StatusOK, expected: http.StatusInternalServerError},
+		{testNum: 5, code: 0, expected: http.StatusInternalServerError},
+	}
+
+	for _, test := range tests {
+		result := HTTPStatus(test.code)
+		if result != test.expected || testutils.FailTests {
+			t.Errorf("\nTest: %d\nInput1..:\n%d\nExpected:\n%d\nGot.....:\n%d",
+				test.testNum, test.code, test.expected, result)
+		}
+	}
+}
+
+func TestWriteHTTPError(t *testing.T) {
+	var tests = []struct {
+		testNum  int
+		err      error
+		status   int
+		expected string
+	}{
+		{
+			testNum: 1,
+			err: &cerror{
+				id:                 "test1",
+				where:              "not available",
+				code:               ErrorNotFound,
+				message:            msg,
+				details:            "error details",
+				recommendedActions: []string{"action1"},
+			},
+			status: http.StatusNotFound,
+			expected: `{"type":"about:blank","title":"Not Found","status":404,"detail":"error details","code":404,` +
+				`"id":"test1","message":"test error text","recommendedActions":["action1"]}`,
+		},
+		{
+			testNum:  2,
+			err:      &cerror{code: ErrorUnknown, message: msg},
+			status:   http.StatusInternalServerError,
+			expected: `{"type":"about:blank","title":"Internal Server Error","status":500,"code":466,"message":"test error text"}`,
+		},
+		{
+			testNum:  3,
+			err:      fmt.Errorf("%s", "std error"),
+			status:   http.StatusInternalServerError,
+			expected: `{"type":"about:blank","title":"Internal Server Error","status":500,"code":500,"message":"std error"}`,
+		},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		if err := WriteHTTPError(w, test.err); err != nil {
+			t.Errorf("\nTest: %d\nFailed to write error: %s", test.testNum, err)
+			continue
+		}
+		if w.Code != test.status || w.Header().Get("Content-Type") != ProblemContentType ||
+			w.Body.String() != test.expected || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%d %s\nGot.....:\n%d %s %s",
+				test.testNum, test.status, test.expected, w.Code, w.Header().Get("Content-Type"), w.Body.String())
+		}
+	}
+
+	if err := WriteHTTPError(httptest.NewRecorder(), nil); err == nil {
+		t.Errorf("\nTest: 4\nExpected error when passed nil error")
+	}
+}
+
+func TestErrorFromHTTPResponse(t *testing.T) {
+	makeResponse := func(status int, contentType, body string) *http.Response {
+		return &http.Response{
+			StatusCode: status,
+			Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+			Header:     http.Header{"Content-Type": []string{contentType}},
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+		}
+	}
+	written := httptest.NewRecorder()
+	sent := &cerror{
+		id:                 "test1",
+		code:               ErrorUnknown,
+		message:            msg,
+		details:            "error details",
+		recommendedActions: []string{"action1", "action2"},
+	}
+	if err := WriteHTTPError(written, sent); err != nil {
+		t.Fatalf("failed to write error: %s", err)
+	}
+
+	var tests = []struct {
+		testNum  int
+		resp     *http.Response
+		expected error
+	}{
+		{
+			testNum: 1,
+			resp:    written.Result(),
+			expected: &cerror{
+				id:                 "test1",
+				where:              "core.TestErrorFromHTTPResponse() - error-http_test.go(NN)",
+				code:               ErrorUnknown,
+				message:            msg,
+				details:            "error details",
+				recommendedActions: []string{"action1", "action2"},
+			},
+		},
+		{
+			testNum: 2,
+			resp:    makeResponse(http.StatusConflict, "text/plain", "already exists\n"),
+			expected: &cerror{
+				where:              "core.TestErrorFromHTTPResponse() - error-http_test.go(NN)",
+				code:               ErrorDuplicateEntry,
+				message:            "already exists",
+				recommendedActions: []string{},
+			},
+		},
+		{
+			testNum: 3,
+			resp:    makeResponse(http.StatusBadGateway, "application/problem+json; charset=utf-8", `{"title":"Bad Gateway","status":502}`),
+			expected: &cerror{
+				where:              "core.TestErrorFromHTTPResponse() - error-http_test.go(NN)",
+				code:               http.StatusBadGateway,
+				message:            "Bad Gateway",
+				recommendedActions: []string{},
+			},
+		},
+		{
+			testNum: 4,
+			resp:    makeResponse(http.StatusServiceUnavailable, "application/json", ""),
+			expected: &cerror{
+				where:              "core.TestErrorFromHTTPResponse() - error-http_test.go(NN)",
+				code:               ErrorServiceUnavailable,
+				message:            "503 Service Unavailable",
+				recommendedActions: []string{},
+			},
+		},
+		{
+			testNum:  5,
+			resp:     makeResponse(http.StatusOK, "application/json", "{}"),
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		result := ErrorFromHTTPResponse(test.resp)
+		if !CompareErrors(result, test.expected) || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%s\nGot.....:\n%s", test.testNum, ErrorText(test.expected), ErrorText(result))
+		}
+	}
+
+	result := ErrorFromHTTPResponse(makeResponse(http.StatusBadGateway, "text/plain", strings.Repeat("x", maxResponseBody+10)))
+	if coreErr, ok := result.(Error); !ok || len(coreErr.Message()) != maxResponseBody || testutils.FailTests {
+		t.Errorf("\nTest: 6\nExpected:\nmessage of %d bytes\nGot.....:\n%T", maxResponseBody, result)
+	}
+}
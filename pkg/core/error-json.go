@@ -0,0 +1,131 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package core
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/paulcarlton/go-utils/pkg/internal/common"
+)
+
+// jsonID is the id of the errors created by ErrorFromJSON
+const jsonID = "json"
+
+// jsonError is the serialized form of a core.Error
+// A nested error that is not a core.Error is serialized with only the text field set
+// Recommended actions are always emitted so an empty list and a nil list survive a round trip
+type jsonError struct {
+	Code               int          `json:"code,omitempty"`
+	CodeText           string       `json:"codeText,omitempty"`
+	Reason             string       `json:"reason,omitempty"`
+	RequestID          string       `json:"requestId,omitempty"`
+	TraceID            string       `json:"traceId,omitempty"`
+	User               string       `json:"user,omitempty"`
+	ID                 string       `json:"id,omitempty"`
+	Message            string       `json:"message,omitempty"`
+	Details            string       `json:"details,omitempty"`
+	RecommendedActions []string     `json:"recommendedActions"`
+	Where              string       `json:"where,omitempty"`
+	StackTrace         []string     `json:"stackTrace,omitempty"`
+	Fields             Fields       `json:"fields,omitempty"`
+	Retryable          *bool        `json:"retryable,omitempty"`
+	Nested             *jsonError   `json:"nested,omitempty"`
+	Errors             []*jsonError `json:"errors,omitempty"`
+	Text               string       `json:"text,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler, serializing the core.Error and its nested errors
+func (e *cerror) MarshalJSON() ([]byte, error) {
+	return json.Marshal(toJSONError(e))
+}
+
+// ErrorFromJSON creates a core.Error from json generated by marshaling a core.Error
+func ErrorFromJSON(data []byte) (Error, error) {
+	var j *jsonError
+	if err := json.Unmarshal(data, &j); err != nil {
+		return nil, RaiseError(jsonID, ErrorInvalidInput, "failed to unmarshal core.Error json", err)
+	}
+	if result, ok := fromJSONError(j).(Error); ok {
+		return result, nil
+	}
+	return nil, MakeError(jsonID, ErrorInvalidInput, "json does not contain a core.Error")
+}
+
+// toJSONError converts an error to its serialized form
+func toJSONError(err error) *jsonError {
+	if err == nil {
+		return nil
+	}
+
+	switch e := err.(type) {
+	case *ErrorList:
+		if e == nil {
+			return nil
+		}
+		j := toJSONError(e.header())
+		for _, member := range e.Errors() {
+			j.Errors = append(j.Errors, toJSONError(member))
+		}
+		return j
+	case *cerror:
+		if e == nil {
+			return nil
+		}
+		return &jsonError{
+			Code:               e.Code(),
+			CodeText:           CodeText(e.Code()),
+			Reason:             e.reason,
+			RequestID:          e.request.requestID,
+			TraceID:            e.request.traceID,
+			User:               e.request.user,
+			ID:                 e.id,
+			Message:            common.RedactText(e.Message()),
+			Details:            common.RedactText(e.Details()),
+			RecommendedActions: e.RecommendedActions(),
+			Where:              e.where,
+			StackTrace:         e.stack,
+			Fields:             e.fields.redacted(),
+			Retryable:          e.retryable,
+			Nested:             toJSONError(e.nestedError),
+		}
+	}
+
+	return &jsonError{Text: common.RedactText(err.Error())}
+}
+
+// fromJSONError converts the serialized form of an error back to an error
+func fromJSONError(j *jsonError) error {
+	if j == nil {
+		return nil
+	}
+
+	if len(j.Text) > 0 {
+		return errors.New(j.Text)
+	}
+
+	result := &cerror{
+		code:               j.Code,
+		reason:             j.Reason,
+		request:            requestInfo{requestID: j.RequestID, traceID: j.TraceID, user: j.User},
+		id:                 j.ID,
+		message:            j.Message,
+		details:            j.Details,
+		where:              j.Where,
+		stack:              j.StackTrace,
+		fields:             j.Fields,
+		retryable:          j.Retryable,
+		recommendedActions: j.RecommendedActions,
+		nestedError:        fromJSONError(j.Nested),
+	}
+
+	if len(j.Errors) > 0 {
+		list := &ErrorList{}
+		result.cloneTo(&list.cerror)
+		for _, member := range j.Errors {
+			list.Append(fromJSONError(member))
+		}
+		return list
+	}
+	return result
+}
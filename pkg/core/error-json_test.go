@@ -0,0 +1,118 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/paulcarlton/go-utils/pkg/testutils"
+)
+
+func TestErrorJSONRoundTrip(t *testing.T) {
+	var tests = []struct {
+		testNum int
+		coreErr *cerror
+	}{
+		{
+			testNum: 1,
+			coreErr: &cerror{
+				id:                 "test1",
+				where:              "core.TestErrorJSONRoundTrip() - error-json_test.go(NN)",
+				code:               ErrorBadRequest,
+				message:            "very bad request",
+				details:            "error details",
+				recommendedActions: []string{"action1", "action2"},
+			},
+		},
+		{
+			testNum: 2,
+			coreErr: &cerror{
+				id:                 "test1",
+				where:              "core.TestErrorJSONRoundTrip() - error-json_test.go(NN)",
+				code:               ErrorUnknown,
+				message:            "failed to do something",
+				recommendedActions: []string{},
+				nestedError: &cerror{
+					id:          "test2",
+					where:       "core.TestErrorJSONRoundTrip() - error-json_test.go(NN)",
+					code:        ErrorNotFound,
+					message:     "try giving me the right data",
+					nestedError: fmt.Errorf("%s", "an instance of a standard error type"),
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		data, err := json.Marshal(test.coreErr)
+		if err != nil {
+			t.Errorf("\nTest: %d\nFailed to marshal: %s", test.testNum, err)
+			continue
+		}
+		result, err := ErrorFromJSON(data)
+		if err != nil {
+			t.Errorf("\nTest: %d\nFailed to unmarshal: %s", test.testNum, ErrorText(err))
+			continue
+		}
+		if !CompareErrors(result, test.coreErr) || testutils.FailTests {
+			t.Errorf("\nTest: %d\nJSON....:\n%s\nExpected:\n%s\nGot.....:\n%s",
+				test.testNum, data, test.coreErr.FullInfo(), result.FullInfo())
+		}
+	}
+}
+
+func TestErrorMarshalJSON(t *testing.T) {
+	var tests = []struct {
+		testNum  int
+		coreErr  *cerror
+		expected string
+	}{
+		{
+			testNum: 1,
+			coreErr: &cerror{
+				id:                 "test1",
+				where:              "not available",
+				code:               ErrorNotFound,
+				message:            msg,
+				recommendedActions: []string{"action1"},
+				nestedError:        fmt.Errorf("%s", "std error"),
+			},
+			expected: `{"code":404,"codeText":"Not Found","id":"test1","message":"test error text",` +
+				`"recommendedActions":["action1"],"where":"not available","nested":{"recommendedActions":null,"text":"std error"}}`,
+		},
+		{
+			testNum:  2,
+			coreErr:  nil,
+			expected: "null",
+		},
+	}
+
+	for _, test := range tests {
+		data, err := test.coreErr.MarshalJSON()
+		if err != nil || string(data) != test.expected || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%s\nGot.....:\n%s, %v", test.testNum, test.expected, data, err)
+		}
+	}
+}
+
+func TestErrorFromJSONErrors(t *testing.T) {
+	var tests = []struct {
+		testNum  int
+		data     string
+		expected int
+	}{
+		{testNum: 1, data: "not json", expected: ErrorInvalidInput},
+		{testNum: 2, data: `{"text":"std error"}`, expected: ErrorInvalidInput},
+		{testNum: 3, data: "null", expected: ErrorInvalidInput},
+	}
+
+	for _, test := range tests {
+		_, err := ErrorFromJSON([]byte(test.data))
+		if coreErr, ok := err.(Error); !ok || coreErr.Code() != test.expected || testutils.FailTests {
+			t.Errorf("\nTest: %d\nInput1..:\n%s\nExpected code:\n%d\nGot.....:\n%s",
+				test.testNum, test.data, test.expected, ErrorText(err))
+		}
+	}
+}
@@ -0,0 +1,255 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/paulcarlton/go-utils/pkg/internal/common"
+)
+
+// ErrorList is a core.Error that aggregates the errors from validating many inputs or running an
+// operation over many items. The id, message, details, recommended actions and fields describe the
+// overall operation while the code is derived from the codes of the errors in the list.
+type ErrorList struct {
+	cerror
+	errors []error
+}
+
+// listCodePrecedence defines the code of an ErrorList, the code of the error in the list that appears
+// first in this list is used. Codes that are not in this list rank after it but before ErrorUnknown.
+var listCodePrecedence = []int{
+	ErrorInternal,
+	ErrorServiceUnavailable,
+	http.StatusTooManyRequests,
+	ErrorNotImplemented,
+	ErrorUnauthorized,
+	ErrorNotAllowed,
+	ErrorDuplicateEntry,
+	ErrorNotFound,
+	ErrorInvalidInput,
+	ErrorBadRequest,
+}
+
+// NewErrorList creates an empty ErrorList
+func NewErrorList(id, msg string) *ErrorList {
+	return newErrorList(id, msg, common.GetCaller(4, true))
+}
+
+// newErrorList creates an empty ErrorList, setting the function and file/line to the value provided
+func newErrorList(id, msg, where string) *ErrorList {
+	return &ErrorList{
+		cerror: cerror{
+			code:               ErrorUnknown,
+			id:                 id,
+			message:            msg,
+			where:              where,
+			recommendedActions: []string{},
+		},
+	}
+}
+
+// Append adds errors to the list, nil errors are ignored
+// It is safe to call from multiple goroutines, such as the workers of a fan-out operation.
+func (l *ErrorList) Append(errs ...error) {
+	if l == nil {
+		return
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	for _, err := range errs {
+		if err != nil {
+			l.errors = append(l.errors, err)
+		}
+	}
+}
+
+// Len returns the number of errors in the list
+func (l *ErrorList) Len() int {
+	if l == nil {
+		return 0
+	}
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	return len(l.errors)
+}
+
+// Errors returns the errors in the list
+func (l *ErrorList) Errors() []error {
+	if l == nil {
+		return nil
+	}
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	return append([]error{}, l.errors...)
+}
+
+// ErrorOrNil returns nil if the list is empty, otherwise it returns the list
+func (l *ErrorList) ErrorOrNil() error {
+	if l.Len() == 0 {
+		return nil
+	}
+	return l
+}
+
+// Code returns the code of the error in the list with the highest precedence or ErrorUnknown if the list is empty
+func (l *ErrorList) Code() int {
+	if l == nil {
+		return ErrorUnknown
+	}
+	code := ErrorUnknown
+	rank := len(listCodePrecedence) + 1
+	for _, err := range l.Errors() {
+		memberCode := ErrorUnknown
+		if coreErr, ok := err.(Error); ok {
+			memberCode = coreErr.Code()
+		}
+		if memberRank := codeRank(memberCode); memberRank < rank {
+			code, rank = memberCode, memberRank
+		}
+	}
+	return code
+}
+
+// codeRank returns the position of a code in listCodePrecedence
+func codeRank(code int) int {
+	if code == ErrorUnknown {
+		return len(listCodePrecedence) + 1
+	}
+	for index, precedent := range listCodePrecedence {
+		if code == precedent {
+			return index
+		}
+	}
+	return len(listCodePrecedence)
+}
+
+// SetCode does nothing since the code of an ErrorList is derived from the errors in the list
+func (l *ErrorList) SetCode() error {
+	if l == nil {
+		return fmt.Errorf(nilErrorObjectPassed)
+	}
+	return nil
+}
+
+// Error returns a string representation of the ErrorList and the errors in it
+func (l *ErrorList) Error() string {
+	if l == nil {
+		return ""
+	}
+	header := l.header()
+	errs := l.Errors()
+	texts := make([]string, 0, len(errs))
+	for _, err := range errs {
+		texts = append(texts, err.Error())
+	}
+	return fmt.Sprintf("%s (%d errors: %s)", header.Error(), len(errs), strings.Join(texts, "; "))
+}
+
+// FullInfo reports all details of the ErrorList and the errors in it
+func (l *ErrorList) FullInfo() string {
+	return l.FullInfoLocale("")
+}
+
+// FullInfoLocale reports all details of the ErrorList and the errors in it, rendering messages created from
+// the catalog in the locale supplied
+func (l *ErrorList) FullInfoLocale(locale string) string {
+	if l == nil {
+		return ""
+	}
+	header := l.header()
+	errorText := header.FullInfoLocale(locale)
+	if errs := l.Errors(); len(errs) > 0 {
+		errorText = fmt.Sprintf("%s\nErrors...", errorText)
+		for _, err := range errs {
+			errorText = fmt.Sprintf("%s\n%s", errorText, ErrorTextLocale(err, locale))
+		}
+	}
+	return errorText
+}
+
+// WithField returns a copy of the ErrorList with the field added
+func (l *ErrorList) WithField(key string, value interface{}) Error {
+	return l.WithFields(Fields{key: value})
+}
+
+// WithFields returns a copy of the ErrorList with the fields added, replacing existing fields with the same key
+func (l *ErrorList) WithFields(fields Fields) Error {
+	return l.with(func(result *cerror) {
+		result.fields = l.fields.merge(fields)
+	})
+}
+
+// WithMessage returns a copy of the ErrorList with the message replaced
+func (l *ErrorList) WithMessage(message string) Error {
+	return l.with(func(result *cerror) {
+		result.setMessage(message)
+	})
+}
+
+// WithDetails returns a copy of the ErrorList with the details replaced
+func (l *ErrorList) WithDetails(details string) Error {
+	return l.with(func(result *cerror) {
+		result.details = details
+	})
+}
+
+// WithRecommendedActions returns a copy of the ErrorList with the recommended actions added
+func (l *ErrorList) WithRecommendedActions(actions ...string) Error {
+	return l.with(func(result *cerror) {
+		result.recommendedActions = append(result.recommendedActions, actions...)
+	})
+}
+
+// with returns a copy of the ErrorList, holding the same errors, with a change applied to its overall details
+func (l *ErrorList) with(change func(result *cerror)) Error {
+	if l == nil {
+		return nil
+	}
+	result := &ErrorList{errors: l.Errors()}
+	l.cerror.cloneTo(&result.cerror)
+	change(&result.cerror)
+	return result
+}
+
+// Is reports whether any error in the list matches target
+func (l *ErrorList) Is(target error) bool {
+	if l == nil {
+		return false
+	}
+	for _, err := range l.Errors() {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first error in the list that matches target, and if so, sets target to that error value
+func (l *ErrorList) As(target interface{}) bool {
+	if l == nil {
+		return false
+	}
+	for _, err := range l.Errors() {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements json.Marshaler, serializing the ErrorList and the errors in it
+func (l *ErrorList) MarshalJSON() ([]byte, error) {
+	return json.Marshal(toJSONError(l))
+}
+
+// header returns a core.Error with the overall details of the ErrorList and its derived code
+func (l *ErrorList) header() *cerror {
+	header := l.cerror.clone()
+	header.code = l.Code()
+	return header
+}
@@ -0,0 +1,168 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/paulcarlton/go-utils/pkg/testutils"
+)
+
+func TestErrorListCode(t *testing.T) {
+	var tests = []struct {
+		testNum  int
+		errs     []error
+		expected int
+	}{
+		{testNum: 1, errs: nil, expected: ErrorUnknown},
+		{testNum: 2, errs: []error{fmt.Errorf("%s", "std error")}, expected: ErrorUnknown},
+		{testNum: 3, errs: []error{
+			&cerror{code: ErrorBadRequest}, &cerror{code: ErrorNotFound}, &cerror{code: ErrorInvalidInput}}, expected: ErrorNotFound},
+		{testNum: 4, errs: []error{
+			&cerror{code: ErrorNotFound}, fmt.Errorf("%s", "std error"), &cerror{code: ErrorInternal}}, expected: ErrorInternal},
+		{testNum: 5, errs: []error{&cerror{code: ErrorUnknown}, &cerror{code: 418}}, expected: 418},
+		{testNum: 6, errs: []error{&cerror{code: 418}, &cerror{code: ErrorBadRequest}}, expected: ErrorBadRequest},
+		{testNum: 7, errs: []error{nil, nil}, expected: ErrorUnknown},
+	}
+
+	for _, test := range tests {
+		list := NewErrorList("test1", "validation failed")
+		list.Append(test.errs...)
+		if list.Code() != test.expected || testutils.FailTests {
+			t.Errorf("\nTest: %d\nInput1..:\n%s\nExpected:\n%d\nGot.....:\n%d", test.testNum, list.FullInfo(), test.expected, list.Code())
+		}
+	}
+}
+
+func TestErrorListConcurrentAppend(t *testing.T) {
+	list := NewErrorList("test1", "fan-out failed")
+	var wg sync.WaitGroup
+	for worker := 0; worker < 10; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for item := 0; item < 10; item++ {
+				list.Append(&cerror{code: ErrorNotFound, message: fmt.Sprintf("worker %d item %d", worker, item)})
+				_ = list.Code()
+				_ = list.Error()
+			}
+		}(worker)
+	}
+	wg.Wait()
+	if list.Len() != 100 || list.Code() != ErrorNotFound || testutils.FailTests {
+		t.Errorf("\nTest: 1\nExpected:\n100 errors, code %d\nGot.....:\n%d errors, code %d", ErrorNotFound, list.Len(), list.Code())
+	}
+}
+
+func TestErrorListErrorOrNil(t *testing.T) {
+	list := NewErrorList("test1", "validation failed")
+	if list.ErrorOrNil() != nil || list.Len() != 0 || testutils.FailTests {
+		t.Errorf("\nTest: 1\nExpected nil for empty list, got:\n%v", list.ErrorOrNil())
+	}
+
+	list.Append(nil, MakeError("item1", ErrorInvalidInput, "bad value"))
+	if err := list.ErrorOrNil(); err == nil || list.Len() != 1 || testutils.FailTests {
+		t.Errorf("\nTest: 2\nExpected list with one error, got:\n%s", ErrorText(err))
+	}
+
+	var nilList *ErrorList
+	nilList.Append(MakeError("item1", ErrorInvalidInput, "bad value"))
+	if nilList.ErrorOrNil() != nil || nilList.Len() != 0 || testutils.FailTests {
+		t.Errorf("\nTest: 3\nExpected nil for nil list")
+	}
+}
+
+func TestErrorListText(t *testing.T) {
+	list := newErrorList("test1", "validation failed", "not available")
+	list.Append(
+		&cerror{id: "item1", where: "not available", code: ErrorInvalidInput, message: "bad value"},
+		fmt.Errorf("%s", "std error"),
+	)
+
+	expected := fmt.Sprintf("not available test1 %s validation failed (2 errors: not available item1 %s bad value; std error)",
+		CodeText(ErrorInvalidInput), CodeText(ErrorInvalidInput))
+	if list.Error() != expected || testutils.FailTests {
+		t.Errorf("\nTest: 1\nExpected:\n%s\nGot.....:\n%s", expected, list.Error())
+	}
+
+	expected = fmt.Sprintf("not available test1 %s validation failed\nErrors...\nnot available item1 %s bad value\nstd error",
+		CodeText(ErrorInvalidInput), CodeText(ErrorInvalidInput))
+	if ErrorText(list) != expected || testutils.FailTests {
+		t.Errorf("\nTest: 2\nExpected:\n%s\nGot.....:\n%s", expected, ErrorText(list))
+	}
+}
+
+func TestErrorListIs(t *testing.T) {
+	stdErr := fmt.Errorf("%s", "std error")
+	list := NewErrorList("test1", "validation failed")
+	list.Append(MakeError("item1", ErrorInvalidInput, "bad value"), RaiseError("item2", ErrorUnknown, "failed", stdErr))
+
+	var tests = []struct {
+		testNum  int
+		target   error
+		expected bool
+	}{
+		{testNum: 1, target: MakeError("", ErrorInvalidInput, "invalid"), expected: true},
+		{testNum: 2, target: MakeError("item2", ErrorUnknown, "unknown"), expected: true},
+		{testNum: 3, target: stdErr, expected: true},
+		{testNum: 4, target: MakeError("", ErrorNotFound, "not found"), expected: false},
+	}
+
+	for _, test := range tests {
+		result := errors.Is(list, test.target)
+		if result != test.expected || testutils.FailTests {
+			t.Errorf("\nTest: %d\nTarget..:\n%s\nExpected:\n%t\nGot.....:\n%t", test.testNum, ErrorText(test.target), test.expected, result)
+		}
+	}
+}
+
+func TestCompareErrorLists(t *testing.T) {
+	makeList := func(msgs ...string) *ErrorList {
+		list := newErrorList("test1", "validation failed", "core.TestCompareErrorLists() - error-list_test.go(NN)")
+		for _, msg := range msgs {
+			list.Append(&cerror{id: "item", code: ErrorInvalidInput, message: msg})
+		}
+		return list
+	}
+	var tests = []struct {
+		testNum  int
+		one      error
+		two      error
+		expected bool
+	}{
+		{testNum: 1, one: makeList("one", "two"), two: makeList("one", "two"), expected: true},
+		{testNum: 2, one: makeList("one", "two"), two: makeList("one", "three"), expected: false},
+		{testNum: 3, one: makeList("one", "two"), two: makeList("one"), expected: false},
+		{testNum: 4, one: makeList("one"), two: &cerror{id: "test1", code: ErrorInvalidInput, message: "validation failed"}, expected: false},
+	}
+
+	for _, test := range tests {
+		result := CompareErrors(test.one, test.two)
+		if result != test.expected || testutils.FailTests {
+			t.Errorf("\nTest: %d\nInput1..:\n%s\nInput2..:\n%s\nExpected:\n%t\nGot.....:\n%t",
+				test.testNum, ErrorText(test.one), ErrorText(test.two), test.expected, result)
+		}
+	}
+}
+
+func TestErrorListJSON(t *testing.T) {
+	list := NewErrorList("test1", "validation failed")
+	list.Append(MakeError("item1", ErrorInvalidInput, "bad value"), fmt.Errorf("%s", "std error"))
+	withFields := list.WithField("count", 2)
+
+	data, err := json.Marshal(withFields)
+	if err != nil {
+		t.Fatalf("failed to marshal: %s", err)
+	}
+	result, err := ErrorFromJSON(data)
+	if err != nil || !CompareErrors(result, withFields) || testutils.FailTests {
+		t.Errorf("\nTest: 1\nJSON....:\n%s\nExpected:\n%s\nGot.....:\n%s", data, ErrorText(withFields), ErrorText(result))
+	}
+	if list.Fields() != nil || testutils.FailTests {
+		t.Errorf("\nTest: 2\nExpected original list to be unchanged, got:\n%s", list.FullInfo())
+	}
+}
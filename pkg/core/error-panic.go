@@ -0,0 +1,190 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package core
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/paulcarlton/go-utils/pkg/internal/common"
+)
+
+// panicFrame is the stack frame of the runtime function that starts a panic, the frame after it is the panic site
+const panicFrame = "runtime.gopanic()"
+
+// Recover converts a panic into a core.Error with a code of ErrorInternal and stores it in err
+// It must be deferred directly, for example 'defer core.Recover(&err)' in a function with a named error result.
+// The error has the panic value as a field, nests the value if it is an error, and records the stack of the
+// panic. Where is set to the function that panicked. If err is nil the panic continues.
+func Recover(err *error) {
+	value := recover()
+	if value == nil {
+		return
+	}
+	if err == nil {
+		panic(value)
+	}
+	*err = panicError(value)
+}
+
+// Go runs fn in a goroutine and returns a channel that receives the error returned by fn, or a core.Error
+// created by Recover if fn panics, and is then closed. The channel receives nil if fn succeeds.
+func Go(fn func() error) <-chan error {
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		errs <- run(fn)
+	}()
+	return errs
+}
+
+// RecoverHandler returns a http.Handler that calls next and, if it panics, writes the core.Error created by
+// Recover to the response using WriteHTTPError. A panic with http.ErrAbortHandler is not recovered so the
+// http server can abort the response. If next has already written to the response the error cannot be
+// reported in it, so the handler panics with the core.Error and the http server logs it and aborts the response.
+func RecoverHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		writer := &recoverWriter{ResponseWriter: w}
+		defer func() {
+			if err == nil {
+				return
+			}
+			if errors.Is(err, http.ErrAbortHandler) {
+				panic(http.ErrAbortHandler)
+			}
+			if writer.written {
+				panic(err)
+			}
+			_ = WriteHTTPError(w, err)
+		}()
+		defer Recover(&err)
+		next.ServeHTTP(writer.wrap(), r)
+	})
+}
+
+// recoverWriter records whether a handler has written to the response
+type recoverWriter struct {
+	http.ResponseWriter
+	written bool
+}
+
+type (
+	// flushWriter is a recoverWriter for a response that supports flushing
+	flushWriter struct{ *recoverWriter }
+	// hijackWriter is a recoverWriter for a response whose connection can be hijacked
+	hijackWriter struct{ *recoverWriter }
+	// flushHijackWriter is a recoverWriter for a response that supports flushing and hijacking
+	flushHijackWriter struct{ *recoverWriter }
+)
+
+// wrap returns the recoverWriter as a response writer that only implements http.Flusher and http.Hijacker
+// if the response writer being wrapped implements them
+func (w *recoverWriter) wrap() http.ResponseWriter {
+	_, flusher := w.ResponseWriter.(http.Flusher)
+	_, hijacker := w.ResponseWriter.(http.Hijacker)
+	switch {
+	case flusher && hijacker:
+		return flushHijackWriter{w}
+	case flusher:
+		return flushWriter{w}
+	case hijacker:
+		return hijackWriter{w}
+	}
+	return w
+}
+
+// WriteHeader records that the response has been written and writes the status
+func (w *recoverWriter) WriteHeader(status int) {
+	w.written = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write records that the response has been written and writes the data
+func (w *recoverWriter) Write(data []byte) (int, error) {
+	w.written = true
+	return w.ResponseWriter.Write(data)
+}
+
+// Unwrap returns the response writer being wrapped, for use by http.ResponseController
+func (w *recoverWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// flush records that the response has been written and flushes it
+func (w *recoverWriter) flush() {
+	w.written = true
+	w.ResponseWriter.(http.Flusher).Flush()
+}
+
+// hijack records that the response can no longer be written and hands over the connection
+func (w *recoverWriter) hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.written = true
+	return w.ResponseWriter.(http.Hijacker).Hijack()
+}
+
+// Flush records that the response has been written and flushes it
+func (w flushWriter) Flush() {
+	w.flush()
+}
+
+// Hijack records that the response can no longer be written and hands over the connection
+func (w hijackWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return w.hijack()
+}
+
+// Flush records that the response has been written and flushes it
+func (w flushHijackWriter) Flush() {
+	w.flush()
+}
+
+// Hijack records that the response can no longer be written and hands over the connection
+func (w flushHijackWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return w.hijack()
+}
+
+// run calls fn, converting a panic into a core.Error
+func run(fn func() error) (err error) {
+	defer Recover(&err)
+	return fn()
+}
+
+// panicError creates a core.Error for a panic value, it must be called by the function that called recover
+func panicError(value interface{}) error {
+	stack := panicStack()
+	where := "not available"
+	if len(stack) > 0 {
+		where = stack[0]
+	}
+	msg := fmt.Sprintf("panic: %v", value)
+
+	var err error
+	if nested, ok := value.(error); ok {
+		err = raiseError("", ErrorInternal, msg, where, nested)
+	} else {
+		err = makeError("", ErrorInternal, msg, where)
+	}
+	cerr := err.(*cerror)
+	cerr.code = ErrorInternal
+	cerr.stack = stack
+	cerr.fields = Fields{"panic": value}
+	return cerr
+}
+
+// panicStack returns the call stack from the function that panicked
+func panicStack() []string {
+	callers, err := common.Callers(maxStackDepth, true)
+	if err != nil {
+		return nil
+	}
+	for index, caller := range callers {
+		if strings.HasPrefix(caller, panicFrame) {
+			return callers[index+1:]
+		}
+	}
+	return callers
+}
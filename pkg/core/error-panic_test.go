@@ -0,0 +1,211 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package core
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/paulcarlton/go-utils/pkg/testutils"
+)
+
+// panicky panics with a value
+func panicky(value interface{}) {
+	panic(value)
+}
+
+// recovered calls panicky and returns the error created by Recover
+func recovered(value interface{}) (err error) {
+	defer Recover(&err)
+	panicky(value)
+	return nil
+}
+
+func TestRecover(t *testing.T) {
+	cause := errors.New("cause")
+	var tests = []struct {
+		testNum  int
+		value    interface{}
+		expected *cerror
+	}{
+		{
+			testNum: 1,
+			value:   "failed",
+			expected: &cerror{
+				code:               ErrorInternal,
+				message:            "panic: failed",
+				where:              "core.panicky() - error-panic_test.go(NN)",
+				recommendedActions: []string{},
+				fields:             Fields{"panic": "failed"},
+			},
+		},
+		{
+			testNum: 2,
+			value:   cause,
+			expected: &cerror{
+				code:               ErrorInternal,
+				message:            "panic: cause",
+				where:              "core.panicky() - error-panic_test.go(NN)",
+				recommendedActions: []string{},
+				fields:             Fields{"panic": cause},
+				nestedError:        cause,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		result := recovered(test.value)
+		coreErr, ok := result.(Error)
+		if !CompareErrors(result, test.expected) || !ok || len(coreErr.StackTrace()) < 2 ||
+			!compareWhere(coreErr.StackTrace()[1], "core.recovered() - error-panic_test.go(NN)") || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%s\nGot.....:\n%s\n%s", test.testNum, test.expected.FullInfo(), ErrorText(result),
+				test.expected.DiffText(result))
+		}
+	}
+}
+
+func TestGo(t *testing.T) {
+	var tests = []struct {
+		testNum  int
+		fn       func() error
+		expected string
+	}{
+		{testNum: 1, fn: func() error { return nil }, expected: ""},
+		{testNum: 2, fn: func() error { return errors.New("failed") }, expected: "failed"},
+		{testNum: 3, fn: func() error { panicky("crashed"); return nil }, expected: "panic: crashed"},
+	}
+
+	for _, test := range tests {
+		errs := Go(test.fn)
+		err := <-errs
+		message := ""
+		if err != nil {
+			message = err.Error()
+			if coreErr, ok := err.(Error); ok {
+				message = coreErr.Message()
+			}
+		}
+		if _, open := <-errs; message != test.expected || open || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%s\nGot.....:\n%s", test.testNum, test.expected, ErrorText(err))
+		}
+	}
+}
+
+func TestRecoverHandler(t *testing.T) {
+	handler := RecoverHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/panic" {
+			panicky("crashed")
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	var tests = []struct {
+		testNum  int
+		path     string
+		status   int
+		expected string
+	}{
+		{testNum: 1, path: "/ok", status: http.StatusNoContent, expected: ""},
+		{testNum: 2, path: "/panic", status: http.StatusInternalServerError, expected: `"message":"panic: crashed"`},
+	}
+
+	for _, test := range tests {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, test.path, nil))
+		if recorder.Code != test.status || !strings.Contains(recorder.Body.String(), test.expected) || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%d %s\nGot.....:\n%d %s", test.testNum, test.status, test.expected, recorder.Code, recorder.Body.String())
+		}
+	}
+
+	abort := RecoverHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+	defer func() {
+		if value := recover(); value != http.ErrAbortHandler || testutils.FailTests {
+			t.Errorf("\nTest: 3\nExpected:\n%v\nGot.....:\n%v", http.ErrAbortHandler, value)
+		}
+	}()
+	abort.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+func TestRecoverHandlerWritten(t *testing.T) {
+	handler := RecoverHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("partial")) // nolint: errcheck
+		panicky("crashed")
+	}))
+
+	recorder := httptest.NewRecorder()
+	defer func() {
+		value := recover()
+		if err, ok := value.(error); !ok || !IsInternal(err) || recorder.Code != http.StatusOK ||
+			recorder.Body.String() != "partial" || testutils.FailTests {
+			t.Errorf("\nTest: 1\nExpected:\npanic with internal error, 200 partial\nGot.....:\n%v, %d %s",
+				value, recorder.Code, recorder.Body.String())
+		}
+	}()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+// hijackRecorder is a response recorder whose connection can be hijacked
+type hijackRecorder struct {
+	*httptest.ResponseRecorder
+	hijacked bool
+}
+
+// Hijack records that the connection was hijacked
+func (r *hijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	r.hijacked = true
+	return nil, nil, nil
+}
+
+// plainWriter is a response writer that supports neither flushing nor hijacking
+type plainWriter struct {
+	http.ResponseWriter
+}
+
+func TestRecoverHandlerInterfaces(t *testing.T) {
+	var flushes, hijacks bool
+	handler := RecoverHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, flushes = w.(http.Flusher)
+		_, hijacks = w.(http.Hijacker)
+		if hijacker, ok := w.(http.Hijacker); ok {
+			hijacker.Hijack() // nolint: errcheck
+			panicky("crashed after hijack")
+		}
+	}))
+
+	var tests = []struct {
+		testNum int
+		writer  http.ResponseWriter
+		flushes bool
+		hijacks bool
+	}{
+		{testNum: 1, writer: httptest.NewRecorder(), flushes: true, hijacks: false},
+		{testNum: 2, writer: plainWriter{httptest.NewRecorder()}, flushes: false, hijacks: false},
+		{testNum: 3, writer: &hijackRecorder{ResponseRecorder: httptest.NewRecorder()}, flushes: true, hijacks: true},
+		{testNum: 4, writer: plainWriter{&hijackRecorder{ResponseRecorder: httptest.NewRecorder()}}, flushes: false, hijacks: false},
+	}
+
+	for _, test := range tests {
+		var value interface{}
+		func() {
+			defer func() { value = recover() }()
+			handler.ServeHTTP(test.writer, httptest.NewRequest(http.MethodGet, "/", nil))
+		}()
+		hijacked := false
+		if recorder, ok := test.writer.(*hijackRecorder); ok {
+			hijacked = recorder.hijacked
+		}
+		if flushes != test.flushes || hijacks != test.hijacks || hijacked != test.hijacks || (value != nil) != test.hijacks ||
+			testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\nflusher %t, hijacker %t\nGot.....:\nflusher %t, hijacker %t, hijacked %t, panic %v",
+				test.testNum, test.flushes, test.hijacks, flushes, hijacks, hijacked, value)
+		}
+	}
+}
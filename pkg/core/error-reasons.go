@@ -0,0 +1,114 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+
+	"github.com/paulcarlton/go-utils/pkg/internal/common"
+)
+
+// Reason is a symbolic, namespaced error code such as "location.uri.scheme-mismatch" that identifies
+// a distinct failure. Each reason maps to one of the HTTP based core error codes and provides a default
+// message and recommended actions.
+type Reason struct {
+	Name               string   // Name is the namespaced reason code, dot separated lower case words
+	Code               int      // Code is the core error code used for errors with this reason
+	Message            string   // Message is the default message for errors with this reason
+	RecommendedActions []string // RecommendedActions are added to errors with this reason
+}
+
+var (
+	reasonsLock sync.RWMutex
+	reasons     = map[string]Reason{}
+
+	// reasonName matches a namespaced reason name, at least two dot separated lower case words
+	reasonName = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*(\.[a-z0-9][a-z0-9-]*)+$`)
+)
+
+// RegisterReason adds a reason to the registry
+// It returns an error if the name is not namespaced or is already registered
+func RegisterReason(reason Reason) error {
+	if !reasonName.MatchString(reason.Name) {
+		return MakeError(reason.Name, ErrorInvalidInput, "reason name must be dot separated lower case words")
+	}
+
+	reasonsLock.Lock()
+	defer reasonsLock.Unlock()
+	if _, ok := reasons[reason.Name]; ok {
+		return MakeError(reason.Name, ErrorDuplicateEntry, "reason already registered")
+	}
+	reason.RecommendedActions = append([]string{}, reason.RecommendedActions...)
+	reasons[reason.Name] = reason
+	return nil
+}
+
+// MustRegisterReason adds a reason to the registry and panics if it cannot be registered
+// It is intended to be called from package init functions
+func MustRegisterReason(reason Reason) {
+	if err := RegisterReason(reason); err != nil {
+		panic(ErrorText(err))
+	}
+}
+
+// LookupReason returns the registered reason with the name supplied
+func LookupReason(name string) (Reason, bool) {
+	reasonsLock.RLock()
+	defer reasonsLock.RUnlock()
+	reason, ok := reasons[name]
+	if ok {
+		reason.RecommendedActions = append([]string{}, reason.RecommendedActions...)
+	}
+	return reason, ok
+}
+
+// MakeReasonError creates a core.Error with the code, default message and recommended actions of a
+// registered reason. If msg is empty the default message of the reason is used. If the reason is not
+// registered the code is ErrorUnknown.
+func MakeReasonError(id, reason, msg string) error {
+	return addStack(makeReasonError(id, reason, msg, common.GetCaller(4, true), nil), StackTracesEnabled())
+}
+
+// RaiseReasonError creates a core.Error from a nested error with the code, default message and recommended
+// actions of a registered reason
+func RaiseReasonError(id, reason, msg string, nested interface{}) error {
+	return addStack(makeReasonError(id, reason, msg, common.GetCaller(4, true), nested), StackTracesEnabled())
+}
+
+// Reason returns the symbolic reason code of the error or an empty string if it has none
+func (e *cerror) Reason() string {
+	if e != nil {
+		return e.reason
+	}
+	return ""
+}
+
+// makeReasonError creates a core.Error for a reason, with a nested error if nested is not nil
+func makeReasonError(id, name, msg, where string, nested interface{}) error {
+	reason, registered := LookupReason(name)
+	if !registered {
+		reason = Reason{Name: name, Code: ErrorUnknown, Message: fmt.Sprintf("unregistered reason %s", name)}
+	}
+	if len(msg) == 0 {
+		msg = reason.Message
+	}
+
+	var err error
+	if nested == nil {
+		err = makeError(id, reason.Code, msg, where)
+	} else {
+		err = raiseError(id, reason.Code, msg, where, nested)
+	}
+
+	if cerr, ok := err.(*cerror); ok {
+		// The code of the reason takes precedence over the code of a nested core.Error
+		if registered {
+			cerr.code = reason.Code
+		}
+		cerr.reason = reason.Name
+		cerr.recommendedActions = append(cerr.recommendedActions, reason.RecommendedActions...)
+	}
+	return err
+}
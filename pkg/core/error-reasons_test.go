@@ -0,0 +1,129 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/paulcarlton/go-utils/pkg/testutils"
+)
+
+// unregisterReason removes a reason from the registry
+func unregisterReason(name string) {
+	reasonsLock.Lock()
+	defer reasonsLock.Unlock()
+	delete(reasons, name)
+}
+
+func TestRegisterReason(t *testing.T) {
+	defer unregisterReason("core.test.registered")
+
+	var tests = []struct {
+		testNum  int
+		reason   Reason
+		expected int
+	}{
+		{testNum: 1, reason: Reason{Name: "core.test.registered", Code: ErrorNotFound}, expected: 0},
+		{testNum: 2, reason: Reason{Name: "core.test.registered", Code: ErrorNotFound}, expected: ErrorDuplicateEntry},
+		{testNum: 3, reason: Reason{Name: "no-namespace", Code: ErrorNotFound}, expected: ErrorInvalidInput},
+		{testNum: 4, reason: Reason{Name: "Core.Test.Upper", Code: ErrorNotFound}, expected: ErrorInvalidInput},
+	}
+
+	for _, test := range tests {
+		err := RegisterReason(test.reason)
+		code := 0
+		if err != nil {
+			code = err.(Error).Code()
+		}
+		if code != test.expected || testutils.FailTests {
+			t.Errorf("\nTest: %d\nInput1..:\n%+v\nExpected:\n%d\nGot.....:\n%s", test.testNum, test.reason, test.expected, ErrorText(err))
+		}
+	}
+}
+
+func TestMakeReasonError(t *testing.T) {
+	defer unregisterReason("core.test.conflict")
+	MustRegisterReason(Reason{
+		Name:               "core.test.conflict",
+		Code:               ErrorDuplicateEntry,
+		Message:            "item already exists",
+		RecommendedActions: []string{"delete the item"},
+	})
+
+	var tests = []struct {
+		testNum  int
+		result   error
+		expected *cerror
+	}{
+		{
+			testNum: 1,
+			result:  MakeReasonError("test1", "core.test.conflict", ""),
+			expected: &cerror{
+				id:                 "test1",
+				where:              "core.TestMakeReasonError() - error-reasons_test.go(NN)",
+				code:               ErrorDuplicateEntry,
+				reason:             "core.test.conflict",
+				message:            "item already exists",
+				recommendedActions: []string{"delete the item"},
+			},
+		},
+		{
+			testNum: 2,
+			result:  RaiseReasonError("test1", "core.test.conflict", "failed to create item", &cerror{code: ErrorNotFound, message: "nested"}),
+			expected: &cerror{
+				id:                 "test1",
+				where:              "core.TestMakeReasonError() - error-reasons_test.go(NN)",
+				code:               ErrorDuplicateEntry,
+				reason:             "core.test.conflict",
+				message:            "failed to create item",
+				recommendedActions: []string{"delete the item"},
+				nestedError:        &cerror{code: ErrorNotFound, message: "nested"},
+			},
+		},
+		{
+			testNum: 3,
+			result:  MakeReasonError("test1", "core.test.unregistered", ""),
+			expected: &cerror{
+				id:                 "test1",
+				where:              "core.TestMakeReasonError() - error-reasons_test.go(NN)",
+				code:               ErrorUnknown,
+				reason:             "core.test.unregistered",
+				message:            "unregistered reason core.test.unregistered",
+				recommendedActions: []string{},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		if !CompareErrors(test.result, test.expected) || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%s\nGot.....:\n%s", test.testNum, test.expected.FullInfo(), ErrorText(test.result))
+		}
+	}
+}
+
+func TestReasonOutput(t *testing.T) {
+	coreErr := &cerror{
+		id:                 "test1",
+		where:              "not available",
+		code:               ErrorDuplicateEntry,
+		reason:             "core.test.conflict",
+		message:            msg,
+		recommendedActions: []string{},
+	}
+	expected := fmt.Sprintf("not available test1 %s %s\nReason: core.test.conflict", CodeText(ErrorDuplicateEntry), msg)
+	if coreErr.FullInfo() != expected || testutils.FailTests {
+		t.Errorf("\nTest: 1\nExpected:\n%s\nGot.....:\n%s", expected, coreErr.FullInfo())
+	}
+
+	data, err := json.Marshal(coreErr)
+	if err != nil || !strings.Contains(string(data), `"reason":"core.test.conflict"`) || testutils.FailTests {
+		t.Errorf("\nTest: 2\nExpected reason in:\n%s", data)
+	}
+	result, err := ErrorFromJSON(data)
+	if err != nil || result.Reason() != coreErr.reason || testutils.FailTests {
+		t.Errorf("\nTest: 3\nExpected:\n%s\nGot.....:\n%s", coreErr.FullInfo(), ErrorText(result))
+	}
+}
@@ -0,0 +1,97 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package core
+
+import (
+	"errors"
+	"net/http"
+)
+
+// retryableCodes are the core error codes of transient failures that may succeed if retried
+var retryableCodes = map[int]bool{
+	ErrorServiceUnavailable:    true,
+	http.StatusTooManyRequests: true,
+	ErrorDuplicateEntry:        true,
+}
+
+// IsRetryable reports whether an operation that failed with err may succeed if it is retried
+// The first core.Error in the nested chain with an explicit retryable flag, set using WithRetryable,
+// decides. Otherwise the error is retryable if its code is ErrorServiceUnavailable, 429 Too Many Requests
+// or ErrorDuplicateEntry, or if it is not a core.Error and reports itself as temporary.
+// An ErrorList is retryable if all the errors in it are retryable.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if retryable, ok := retryFlag(err); ok {
+		return retryable
+	}
+	if coreErr, ok := err.(Error); ok {
+		return retryableCodes[coreErr.Code()]
+	}
+	var temporary interface{ Temporary() bool }
+	if errors.As(err, &temporary) {
+		return temporary.Temporary()
+	}
+	return false
+}
+
+// IsPermanent reports whether an operation that failed with err will fail again if it is retried
+func IsPermanent(err error) bool {
+	return err != nil && !IsRetryable(err)
+}
+
+// WithRetryable returns a copy of the core.Error with the retryable flag set, overriding the classification
+// derived from the error code
+func (e *cerror) WithRetryable(retryable bool) Error {
+	return e.with(func(result *cerror) {
+		result.retryable = &retryable
+	})
+}
+
+// Retryable reports whether the operation that failed with this error may succeed if it is retried
+func (e *cerror) Retryable() bool {
+	if e == nil {
+		return false
+	}
+	return IsRetryable(e)
+}
+
+// WithRetryable returns a copy of the ErrorList with the retryable flag set
+func (l *ErrorList) WithRetryable(retryable bool) Error {
+	return l.with(func(result *cerror) {
+		result.retryable = &retryable
+	})
+}
+
+// Retryable reports whether the retryable flag is set or, if it is not set, whether all the errors in the list are retryable
+func (l *ErrorList) Retryable() bool {
+	if l == nil {
+		return false
+	}
+	if l.retryable != nil {
+		return *l.retryable
+	}
+	errs := l.Errors()
+	for _, err := range errs {
+		if !IsRetryable(err) {
+			return false
+		}
+	}
+	return len(errs) > 0
+}
+
+// retryFlag returns the explicit retryable flag of the first error in the nested chain that has one
+func retryFlag(err error) (bool, bool) {
+	for ; err != nil; err = nestedError(err) {
+		switch e := err.(type) {
+		case *ErrorList:
+			return e.Retryable(), true
+		case *cerror:
+			if e.retryable != nil {
+				return *e.retryable, true
+			}
+		}
+	}
+	return false, false
+}
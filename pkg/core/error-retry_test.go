@@ -0,0 +1,66 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/paulcarlton/go-utils/pkg/testutils"
+)
+
+func TestIsRetryable(t *testing.T) {
+	unavailable := &cerror{code: ErrorServiceUnavailable, message: "unavailable"}
+	notFound := &cerror{code: ErrorNotFound, message: "not found"}
+	list := newErrorList("list", msg, "not available")
+	list.Append(unavailable, &cerror{code: http.StatusTooManyRequests})
+	mixed := newErrorList("list", msg, "not available")
+	mixed.Append(unavailable, notFound)
+
+	var tests = []struct {
+		testNum  int
+		err      error
+		expected bool
+	}{
+		{testNum: 1, err: nil, expected: false},
+		{testNum: 2, err: unavailable, expected: true},
+		{testNum: 3, err: &cerror{code: http.StatusTooManyRequests}, expected: true},
+		{testNum: 4, err: &cerror{code: ErrorDuplicateEntry}, expected: true},
+		{testNum: 5, err: notFound, expected: false},
+		{testNum: 6, err: notFound.WithRetryable(true), expected: true},
+		{testNum: 7, err: unavailable.WithRetryable(false), expected: false},
+		{testNum: 8, err: &cerror{code: ErrorInternal, nestedError: notFound.WithRetryable(true)}, expected: true},
+		{testNum: 9, err: errors.New("failed"), expected: false},
+		{testNum: 10, err: &net.DNSError{IsTemporary: true}, expected: true},
+		{testNum: 11, err: list, expected: true},
+		{testNum: 12, err: mixed, expected: false},
+		{testNum: 13, err: mixed.WithRetryable(true), expected: true},
+		{testNum: 14, err: newErrorList("list", msg, "not available"), expected: false},
+	}
+
+	for _, test := range tests {
+		result := IsRetryable(test.err)
+		if result != test.expected || IsPermanent(test.err) != (test.err != nil && !test.expected) || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%t\nGot.....:\n%t\n%s", test.testNum, test.expected, result, ErrorText(test.err))
+		}
+	}
+
+	if unavailable.retryable != nil || mixed.retryable != nil || testutils.FailTests {
+		t.Errorf("\nTest: 15\nExpected:\noriginal errors unchanged by WithRetryable")
+	}
+}
+
+func TestRetryableJSON(t *testing.T) {
+	coreErr := (&cerror{id: "test1", code: ErrorNotFound, message: msg, recommendedActions: []string{}}).WithRetryable(true)
+	data, err := json.Marshal(coreErr)
+	if err != nil {
+		t.Fatalf("failed to marshal: %s", err)
+	}
+	result, err := ErrorFromJSON(data)
+	if err != nil || !result.Retryable() || !CompareErrors(result, coreErr) || testutils.FailTests {
+		t.Errorf("\nTest: 1\nExpected:\n%s\nGot.....:\n%s", data, ErrorText(result))
+	}
+}
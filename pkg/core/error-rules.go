@@ -0,0 +1,131 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package core
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+type (
+	// ClassifierMatchI defines the function called to determine if a classifier applies to an error
+	ClassifierMatchI func(e Error) bool
+
+	// Classifier is a rule used to set the code of a core.Error created with a code of ErrorUnknown
+	// Classifiers are registered using RegisterClassifier and are applied in the order they were registered,
+	// the first one that matches sets the code.
+	Classifier struct {
+		Name  string           // Name identifies the classifier
+		Code  int              // Code is the core error code to set when the classifier matches
+		Match ClassifierMatchI // Match returns true if the classifier applies to the error
+	}
+)
+
+var (
+	classifiersLock sync.RWMutex
+	classifiers     []Classifier
+)
+
+func init() {
+	// vault emits 'permission error'
+	RegisterClassifier(SubstringClassifier("core.permission-error", ErrorUnauthorized, "permission error"))
+}
+
+// RegisterClassifier adds a classifier to the list applied by SetCode
+// Libraries should register their classifiers in an init function
+func RegisterClassifier(classifier Classifier) {
+	classifiersLock.Lock()
+	defer classifiersLock.Unlock()
+	classifiers = append(classifiers, classifier)
+}
+
+// Classifiers returns the registered classifiers in the order they are applied
+func Classifiers() []Classifier {
+	classifiersLock.RLock()
+	defer classifiersLock.RUnlock()
+	return append([]Classifier{}, classifiers...)
+}
+
+// Classify returns the code set by the first registered classifier that matches the error
+// It returns ErrorUnknown if no classifier matches
+func Classify(e Error) int {
+	for _, classifier := range Classifiers() {
+		if classifier.Match != nil && classifier.Match(e) {
+			return classifier.Code
+		}
+	}
+	return ErrorUnknown
+}
+
+// SubstringClassifier returns a classifier that matches if the message or text of a nested error contains substring
+func SubstringClassifier(name string, code int, substring string) Classifier {
+	return Classifier{
+		Name: name,
+		Code: code,
+		Match: func(e Error) bool {
+			return strings.Contains(classifyText(e), substring)
+		},
+	}
+}
+
+// RegexpClassifier returns a classifier that matches if the message or text of a nested error matches pattern
+func RegexpClassifier(name string, code int, pattern *regexp.Regexp) Classifier {
+	return Classifier{
+		Name: name,
+		Code: code,
+		Match: func(e Error) bool {
+			return pattern.MatchString(classifyText(e))
+		},
+	}
+}
+
+// NestedTypeClassifier returns a classifier that matches if an error in the nested error chain has the
+// same type as sample
+func NestedTypeClassifier(name string, code int, sample error) Classifier {
+	sampleType := reflect.TypeOf(sample)
+	return PredicateClassifier(name, code, func(err error) bool {
+		return reflect.TypeOf(err) == sampleType
+	})
+}
+
+// PredicateClassifier returns a classifier that matches if predicate returns true for the error or any error
+// in its nested error chain
+func PredicateClassifier(name string, code int, predicate func(err error) bool) Classifier {
+	return Classifier{
+		Name: name,
+		Code: code,
+		Match: func(e Error) bool {
+			var err error = e
+			for err != nil {
+				if predicate(err) {
+					return true
+				}
+				err = nestedError(err)
+			}
+			return false
+		},
+	}
+}
+
+// classifyText returns the message and the text of any nested error for use by classifiers
+func classifyText(e Error) string {
+	text := e.Message()
+	if nested := e.Nested(); nested != nil {
+		text = fmt.Sprintf("%s, %s", text, nested)
+	}
+	return text
+}
+
+// nestedError returns the next error in the chain of a core.Error or an error that implements Unwrap
+func nestedError(err error) error {
+	switch e := err.(type) {
+	case Error:
+		return e.Nested()
+	case interface{ Unwrap() error }:
+		return e.Unwrap()
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package core
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"testing"
+
+	"github.com/paulcarlton/go-utils/pkg/testutils"
+)
+
+// restoreClassifiers resets the registered classifiers to those supplied
+func restoreClassifiers(saved []Classifier) {
+	classifiersLock.Lock()
+	defer classifiersLock.Unlock()
+	classifiers = saved
+}
+
+func TestClassifiers(t *testing.T) {
+	defer restoreClassifiers(Classifiers())
+
+	RegisterClassifier(SubstringClassifier("test.not-found", ErrorNotFound, "not found"))
+	RegisterClassifier(RegexpClassifier("test.conflict", ErrorDuplicateEntry, regexp.MustCompile(`(?i)already\s+exists`)))
+	RegisterClassifier(NestedTypeClassifier("test.url", ErrorServiceUnavailable, &url.Error{}))
+	RegisterClassifier(PredicateClassifier("test.predicate", ErrorNotImplemented, func(err error) bool {
+		return err.Error() == "not implemented yet"
+	}))
+	RegisterClassifier(SubstringClassifier("test.shadowed", ErrorInternal, "not found"))
+
+	var tests = []struct {
+		testNum  int
+		code     int
+		msg      string
+		nested   interface{}
+		expected int
+	}{
+		{testNum: 1, code: ErrorUnknown, msg: "item not found", expected: ErrorNotFound},
+		{testNum: 2, code: ErrorUnknown, msg: "failed to get item", nested: fmt.Errorf("%s", "item not found"), expected: ErrorNotFound},
+		{testNum: 3, code: ErrorUnknown, msg: "failed to create item", nested: fmt.Errorf("%s", "item Already Exists"), expected: ErrorDuplicateEntry},
+		{testNum: 4, code: ErrorUnknown, msg: "failed to connect",
+			nested: &url.Error{Op: "Get", URL: "http://localhost", Err: fmt.Errorf("%s", "refused")}, expected: ErrorServiceUnavailable},
+		{testNum: 5, code: ErrorUnknown, msg: "failed to do something",
+			nested: fmt.Errorf("wrapped: %w", fmt.Errorf("%s", "not implemented yet")), expected: ErrorNotImplemented},
+		{testNum: 6, code: ErrorBadRequest, msg: "item not found", expected: ErrorBadRequest},
+		{testNum: 7, code: ErrorUnknown, msg: "something about a permission error etc", expected: ErrorUnauthorized},
+		{testNum: 8, code: ErrorUnknown, msg: "something else", expected: ErrorUnknown},
+	}
+
+	for _, test := range tests {
+		var result error
+		if test.nested == nil {
+			result = MakeError("test1", test.code, test.msg)
+		} else {
+			result = RaiseError("test1", test.code, test.msg, test.nested)
+		}
+		if result.(Error).Code() != test.expected || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%d\nGot.....:\n%s", test.testNum, test.expected, ErrorText(result))
+		}
+	}
+}
+
+func TestClassify(t *testing.T) {
+	var tests = []struct {
+		testNum  int
+		coreErr  *cerror
+		expected int
+	}{
+		{testNum: 1, coreErr: &cerror{code: ErrorUnknown, message: "permission error"}, expected: ErrorUnauthorized},
+		{testNum: 2, coreErr: &cerror{code: ErrorUnknown, message: "something else"}, expected: ErrorUnknown},
+	}
+
+	for _, test := range tests {
+		result := Classify(test.coreErr)
+		if result != test.expected || testutils.FailTests {
+			t.Errorf("\nTest: %d\nInput1..:\n%s\nExpected:\n%d\nGot.....:\n%d",
+				test.testNum, test.coreErr.FullInfo(), test.expected, result)
+		}
+	}
+}
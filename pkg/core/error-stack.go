@@ -0,0 +1,66 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package core
+
+import (
+	"sync/atomic"
+
+	"github.com/paulcarlton/go-utils/pkg/internal/common"
+)
+
+const (
+	// maxStackDepth is the maximum number of call frames recorded in a stack trace
+	maxStackDepth = 64
+	// stackSkip is the number of frames to skip to get to the caller of the core.Error constructor
+	// common.Callers, addStack and the constructor
+	stackSkip = 3
+)
+
+// captureStacks is set to non zero to record a stack trace in every core.Error created
+var captureStacks int32
+
+// EnableStackTraces sets whether all core.Error values created record the call stack
+// Stack traces are disabled by default, use MakeErrorWithStack or RaiseErrorWithStack
+// to record the call stack for a single error
+func EnableStackTraces(enable bool) {
+	var value int32
+	if enable {
+		value = 1
+	}
+	atomic.StoreInt32(&captureStacks, value)
+}
+
+// StackTracesEnabled returns true if all core.Error values created record the call stack
+func StackTracesEnabled() bool {
+	return atomic.LoadInt32(&captureStacks) != 0
+}
+
+// MakeErrorWithStack creates a core.Error recording the call stack
+func MakeErrorWithStack(id string, code int, msg string) error {
+	return addStack(makeError(id, code, msg, common.GetCaller(4, true)), true)
+}
+
+// RaiseErrorWithStack creates a core.Error from a nested error recording the call stack
+func RaiseErrorWithStack(id string, code int, msg string, nested interface{}) error {
+	return addStack(raiseError(id, code, msg, common.GetCaller(4, true), nested), true)
+}
+
+// StackTrace returns a copy of the call stack recorded when the error was created or nil if it was not recorded
+func (e *cerror) StackTrace() []string {
+	if e != nil && e.stack != nil {
+		return append([]string{}, e.stack...)
+	}
+	return nil
+}
+
+// addStack records the call stack of the caller of the function calling addStack if capture is true
+func addStack(err error, capture bool) error {
+	cerr, ok := err.(*cerror)
+	if !ok || !capture {
+		return err
+	}
+	if callers, e := common.Callers(maxStackDepth, true); e == nil && len(callers) > stackSkip {
+		cerr.stack = callers[stackSkip:]
+	}
+	return err
+}
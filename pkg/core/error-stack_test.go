@@ -0,0 +1,86 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package core
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/paulcarlton/go-utils/pkg/testutils"
+)
+
+func TestMakeErrorWithStack(t *testing.T) {
+	testFunc := func() error {
+		return MakeErrorWithStack("test1", ErrorBadRequest, msg)
+	}
+	expected := []string{
+		"core.TestMakeErrorWithStack.func1() - error-stack_test.go(NN)",
+		"core.TestMakeErrorWithStack() - error-stack_test.go(NN)",
+	}
+	err := testFunc()
+	stack := testutils.RemoveBottom(err.(Error).StackTrace())
+	if !testutils.CompareWhereList(expected, stack) || testutils.FailTests {
+		t.Errorf("\nTest: 1\nExpected:\n%s\nGot.....:\n%s", testutils.DisplayStrings(expected), testutils.DisplayStrings(stack))
+	}
+	if !compareWhere(err.(Error).Where(), expected[0]) || testutils.FailTests {
+		t.Errorf("\nTest: 2\nExpected:\n%s\nGot.....:\n%s", expected[0], err.(Error).Where())
+	}
+	if !strings.Contains(err.(Error).FullInfo(), "\nStack trace...\ncore.TestMakeErrorWithStack.func1() - error-stack_test.go(") ||
+		testutils.FailTests {
+		t.Errorf("\nTest: 3\nExpected stack trace in:\n%s", err.(Error).FullInfo())
+	}
+
+	err = RaiseErrorWithStack("test1", ErrorBadRequest, msg, fmt.Errorf("%s", "std error"))
+	expected = []string{"core.TestMakeErrorWithStack() - error-stack_test.go(NN)"}
+	stack = testutils.RemoveBottom(err.(Error).StackTrace())
+	if !testutils.CompareWhereList(expected, stack) || testutils.FailTests {
+		t.Errorf("\nTest: 4\nExpected:\n%s\nGot.....:\n%s", testutils.DisplayStrings(expected), testutils.DisplayStrings(stack))
+	}
+}
+
+func TestEnableStackTraces(t *testing.T) {
+	defer EnableStackTraces(StackTracesEnabled())
+
+	EnableStackTraces(false)
+	if err := MakeError("test1", ErrorBadRequest, msg); err.(Error).StackTrace() != nil || testutils.FailTests {
+		t.Errorf("\nTest: 1\nExpected no stack trace, got:\n%s", testutils.DisplayStrings(err.(Error).StackTrace()))
+	}
+
+	EnableStackTraces(true)
+	expected := []string{"core.TestEnableStackTraces() - error-stack_test.go(NN)"}
+	for index, err := range []error{
+		MakeError("test1", ErrorBadRequest, msg),
+		MakeErrorAt("test1", ErrorBadRequest, msg, "somewhere() - something.go (NN)"),
+		RaiseError("test1", ErrorBadRequest, msg, fmt.Errorf("%s", "std error")),
+		RaiseErrorAt("test1", ErrorBadRequest, msg, "somewhere() - something.go (NN)", fmt.Errorf("%s", "std error")),
+	} {
+		stack := testutils.RemoveBottom(err.(Error).StackTrace())
+		if !testutils.CompareWhereList(expected, stack) || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%s\nGot.....:\n%s", index+2, testutils.DisplayStrings(expected), testutils.DisplayStrings(stack))
+		}
+	}
+}
+
+func TestCompareStackTrace(t *testing.T) {
+	var tests = []struct {
+		testNum  int
+		one      []string
+		two      []string
+		expected bool
+	}{
+		{testNum: 1, one: nil, two: []string{"core.Test() - error-stack_test.go(12)"}, expected: true},
+		{testNum: 2, one: []string{"core.Test() - error-stack_test.go(NN)"}, two: []string{"core.Test() - error-stack_test.go(12)"}, expected: true},
+		{testNum: 3, one: []string{"core.Test() - error-stack_test.go(NN)"}, two: []string{"core.Other() - error-stack_test.go(12)"}, expected: false},
+		{testNum: 4, one: []string{"core.Test() - error-stack_test.go(NN)"},
+			two: []string{"core.Test() - error-stack_test.go(12)", "testing.tRunner() - testing.go(12)"}, expected: false},
+	}
+
+	for _, test := range tests {
+		result := CompareErrors(&cerror{code: ErrorBadRequest, stack: test.one}, &cerror{code: ErrorBadRequest, stack: test.two})
+		if result != test.expected || testutils.FailTests {
+			t.Errorf("\nTest: %d\nInput1..:\n%s\nInput2..:\n%s\nExpected:\n%t\nGot.....:\n%t", test.testNum,
+				testutils.DisplayStrings(test.one), testutils.DisplayStrings(test.two), test.expected, result)
+		}
+	}
+}
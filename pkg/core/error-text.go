@@ -4,27 +4,36 @@ package core
 
 import (
 	"fmt"
+
+	"github.com/paulcarlton/go-utils/pkg/internal/common"
 )
 
 // ErrorText returns full details for core.Error and tries to send
 // stringable data for other types of errors or types. This function
 // can be extended to handle other types of error objects when needed.
+// Sensitive values are redacted, see goutils.EnableRedaction
 func ErrorText(e interface{}) string {
+	return ErrorTextLocale(e, "")
+}
+
+// ErrorTextLocale returns the same text as ErrorText but with core.Error messages created from the
+// catalog rendered in the locale supplied
+func ErrorTextLocale(e interface{}, locale string) string {
 	// Is this a core.Error?
 	if err, ok := e.(Error); ok {
-		return err.FullInfo()
+		return err.FullInfoLocale(locale)
 	}
 
 	// Is this a std error?
 	if err, ok := e.(error); ok {
-		return err.Error()
+		return common.RedactText(err.Error())
 	}
 
 	// Is this a stringable object?
 	if text, ok := e.(fmt.Stringer); ok {
-		return text.String()
+		return common.RedactText(text.String())
 	}
 
 	// Format the object and fields
-	return fmt.Sprintf("%+v", e)
+	return common.RedactText(fmt.Sprintf("%+v", e))
 }
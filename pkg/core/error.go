@@ -5,7 +5,7 @@ package core
 import (
 	"fmt"
 	"net/http"
-	"strings"
+	"sync"
 
 	"github.com/paulcarlton/go-utils/pkg/internal/common"
 )
@@ -24,12 +24,29 @@ type (
 		AddDetails(details string) error
 		Details() string
 		AddRecommendedActions(actions ...string) error
+		WithMessage(message string) Error
+		WithDetails(details string) Error
+		WithRecommendedActions(actions ...string) Error
 		FullInfo() string
+		FullInfoLocale(locale string) string
 		Where() string
 		Nested() error
 		RecommendedActions() []string
+		StackTrace() []string
+		WithField(key string, value interface{}) Error
+		WithFields(fields Fields) Error
+		Fields() Fields
+		Reason() string
+		WithRetryable(retryable bool) Error
+		Retryable() bool
+		RequestID() string
+		TraceID() string
+		User() string
 	}
 
+	// Fields holds key/value context information about an error, such as a namespace, uri or attempt number
+	Fields map[string]interface{}
+
 	cerror struct {
 		// code: an opaque string uniquely identifying the error for programmatic use
 		code int
@@ -45,6 +62,26 @@ type (
 		id string
 		// nestedError: subsidiary error that led to this error condition
 		nestedError error
+		// stack: optional call stack recorded when the error was created
+		stack []string
+		// fields: key/value context information about the error
+		fields Fields
+		// reason: optional symbolic, namespaced code identifying the error, see RegisterReason
+		reason string
+		// messageKey: optional catalog key used to render the message and recommended actions in other locales
+		messageKey string
+		// messageArgs: arguments used to fill the catalog templates
+		messageArgs Fields
+		// catalogActions: number of recommended actions that were rendered from the catalog
+		catalogActions int
+		// retryable: optional flag overriding the retryable classification derived from the code, see IsRetryable
+		retryable *bool
+		// request: optional identifiers of the request the error was created for, see MakeErrorCtx
+		request requestInfo
+		// immutable: set by Builder.Build to make the mutator methods fail
+		immutable bool
+		// lock: serializes the mutator methods with the methods reading the values they set
+		lock sync.RWMutex
 	}
 )
 
@@ -72,57 +109,73 @@ const (
 
 	// private error constants
 	nilErrorObjectPassed string = "called with a nil error object"
+	immutableErrorObject string = "called with an immutable error object"
 )
 
-var statusText = map[int]string{
-	ErrorUnknown: "Unknown Error",
-}
-
 // CodeText returns a text for the cor error code. It returns the empty string if the code is not defined
+// The text for codes that are not http statuses is held in the catalog
 func CodeText(code int) string {
 	if httpText := http.StatusText(code); len(httpText) > 0 {
 		return httpText
 	}
-	return statusText[code]
+	text, _ := codeText(code, DefaultLocale)
+	return text
+}
+
+// CodeTextLocale returns a text for the core error code in a locale, the CodeText is returned if the catalog
+// does not hold a text for the code in the locale
+func CodeTextLocale(code int, locale string) string {
+	if len(locale) > 0 {
+		if text, ok := codeText(code, locale); ok {
+			return text
+		}
+	}
+	return CodeText(code)
 }
 
-// SetCode sets the core.Error Code based on search of error text
-// The current implementation only checks for 'permission error' which vault emits
-// The idea is that as we encounter other cases of error text we can use to set the error
-// code they will be added
+// SetCode sets the core.Error Code using the registered classifiers if the code is ErrorUnknown
+// The classifiers search the error text, including the text of any nested error, or inspect the
+// nested errors. The first classifier that matches sets the code. A 'permission error' classifier
+// is registered by default since vault emits this text. Other packages can register classifiers
+// using RegisterClassifier.
 func (e *cerror) SetCode() error {
 	if e == nil {
 		return fmt.Errorf(nilErrorObjectPassed)
 	}
 
-	if e.code == ErrorUnknown &&
-		strings.Contains(e.message, "permission error") {
-		e.code = ErrorUnauthorized
+	if e.Code() != ErrorUnknown {
+		return nil
 	}
-
-	return nil
+	code := Classify(e)
+	return e.update(func(e *cerror) {
+		if e.code == ErrorUnknown {
+			e.code = code
+		}
+	})
 }
 
 // Code an opaque string uniquely identifying the error for programmatic or reference usage
 func (e *cerror) Code() int {
 	if e != nil {
+		e.lock.RLock()
+		defer e.lock.RUnlock()
 		return e.code
 	}
 	return ErrorUnknown
 }
 
-// SetMessage adds message to a core.Error
+// SetMessage adds message to a core.Error, a message created from the catalog is no longer rendered in other locales
 func (e *cerror) SetMessage(message string) error {
-	if e == nil {
-		return fmt.Errorf(nilErrorObjectPassed)
-	}
-	e.message = message
-	return nil
+	return e.update(func(e *cerror) {
+		e.setMessage(message)
+	})
 }
 
 // Message gets Message of a core.Error
 func (e *cerror) Message() string {
 	if e != nil {
+		e.lock.RLock()
+		defer e.lock.RUnlock()
 		return e.message
 	}
 	return ""
@@ -130,16 +183,16 @@ func (e *cerror) Message() string {
 
 // AddDetails adds details to a core.Error
 func (e *cerror) AddDetails(details string) error {
-	if e == nil {
-		return fmt.Errorf(nilErrorObjectPassed)
-	}
-	e.details = details
-	return nil
+	return e.update(func(e *cerror) {
+		e.details = details
+	})
 }
 
 // Details gets details of a core.Error
 func (e *cerror) Details() string {
 	if e != nil {
+		e.lock.RLock()
+		defer e.lock.RUnlock()
 		return e.details
 	}
 	return ""
@@ -147,13 +200,91 @@ func (e *cerror) Details() string {
 
 // AddActions adds recommended actions to a core.Error
 func (e *cerror) AddRecommendedActions(actions ...string) error {
+	return e.update(func(e *cerror) {
+		e.recommendedActions = append(e.recommendedActions, actions...)
+	})
+}
+
+// WithMessage returns a copy of the core.Error with the message replaced
+func (e *cerror) WithMessage(message string) Error {
+	return e.with(func(result *cerror) {
+		result.setMessage(message)
+	})
+}
+
+// WithDetails returns a copy of the core.Error with the details replaced
+func (e *cerror) WithDetails(details string) Error {
+	return e.with(func(result *cerror) {
+		result.details = details
+	})
+}
+
+// WithRecommendedActions returns a copy of the core.Error with the recommended actions added
+func (e *cerror) WithRecommendedActions(actions ...string) Error {
+	return e.with(func(result *cerror) {
+		result.recommendedActions = append(result.recommendedActions, actions...)
+	})
+}
+
+// update applies a change to a core.Error, failing if the error is immutable
+func (e *cerror) update(change func(e *cerror)) error {
 	if e == nil {
 		return fmt.Errorf(nilErrorObjectPassed)
 	}
-	e.recommendedActions = append(e.recommendedActions, actions...)
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if e.immutable {
+		return fmt.Errorf(immutableErrorObject)
+	}
+	change(e)
 	return nil
 }
 
+// clone returns a copy of a core.Error that can be changed without affecting the original
+func (e *cerror) clone() *cerror {
+	result := &cerror{}
+	e.cloneTo(result)
+	return result
+}
+
+// cloneTo copies a core.Error to result, which has its own lock and recommended actions
+func (e *cerror) cloneTo(result *cerror) {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+	result.code = e.code
+	result.message = e.message
+	result.details = e.details
+	result.recommendedActions = append([]string{}, e.recommendedActions...)
+	result.where = e.where
+	result.id = e.id
+	result.nestedError = e.nestedError
+	result.stack = e.stack
+	result.fields = e.fields
+	result.reason = e.reason
+	result.messageKey = e.messageKey
+	result.messageArgs = e.messageArgs
+	result.catalogActions = e.catalogActions
+	result.retryable = e.retryable
+	result.request = e.request
+	result.immutable = e.immutable
+}
+
+// with returns a copy of a core.Error with a change applied
+func (e *cerror) with(change func(result *cerror)) Error {
+	if e == nil {
+		return nil
+	}
+	result := e.clone()
+	change(result)
+	return result
+}
+
+// setMessage replaces the message, dropping the catalog key so the message is reported as set in every locale
+func (e *cerror) setMessage(message string) {
+	e.message = message
+	e.messageKey = ""
+}
+
 func (e *cerror) addNested(nested error) {
 	if e != nil {
 		e.nestedError = nested
@@ -165,41 +296,69 @@ func (e *cerror) Error() string {
 	if e == nil {
 		return ""
 	}
+	return e.text("")
+}
 
+// text returns a string representation of core.Error with the message and code text in a locale
+func (e *cerror) text(locale string) string {
 	sep := " "
 	if len(e.id) == 0 {
 		sep = ""
 	}
-	errorText := fmt.Sprintf("%s%s%s %s %s", e.where, sep, e.id, CodeText(e.code), e.message)
-
-	return errorText
+	return fmt.Sprintf("%s%s%s %s %s", e.where, sep, e.id, CodeTextLocale(e.Code(), locale), e.localeMessage(locale))
 }
 
 // FullInfo reports all details of core.Error
 func (e *cerror) FullInfo() string {
+	return e.FullInfoLocale("")
+}
+
+// FullInfoLocale reports all details of core.Error, rendering messages and recommended actions created from
+// the catalog in the locale supplied. Other messages are reported as they were created.
+func (e *cerror) FullInfoLocale(locale string) string {
 	if e == nil {
 		return ""
 	}
 
-	errorText := e.Error()
+	errorText := e.text(locale)
 
-	if len(e.details) > 0 {
-		errorText = fmt.Sprintf("%s\n%s", errorText, e.details)
+	if len(e.reason) > 0 {
+		errorText = fmt.Sprintf("%s\nReason: %s", errorText, e.reason)
 	}
 
-	if len(e.recommendedActions) > 0 {
+	errorText += e.request.text()
+
+	if details := e.Details(); len(details) > 0 {
+		errorText = fmt.Sprintf("%s\n%s", errorText, details)
+	}
+
+	if actions := e.localeActions(locale); len(actions) > 0 {
 		errorText = fmt.Sprintf("%s\nRecommended actions...", errorText)
-		for _, rec := range e.recommendedActions {
+		for _, rec := range actions {
 			errorText = fmt.Sprintf("%s\n%s", errorText, rec)
 		}
 	}
 
+	if len(e.fields) > 0 {
+		errorText = fmt.Sprintf("%s\nFields...", errorText)
+		for _, field := range e.fields.text() {
+			errorText = fmt.Sprintf("%s\n%s", errorText, field)
+		}
+	}
+
+	if len(e.stack) > 0 {
+		errorText = fmt.Sprintf("%s\nStack trace...", errorText)
+		for _, caller := range e.stack {
+			errorText = fmt.Sprintf("%s\n%s", errorText, caller)
+		}
+	}
+
 	if e.nestedError != nil {
 		errorText = fmt.Sprintf("%s\nNested Errors...", errorText)
 		nested := e.nestedError
-		errorText = fmt.Sprintf("%s\n%s", errorText, ErrorText(nested))
+		errorText = fmt.Sprintf("%s\n%s", errorText, ErrorTextLocale(nested, locale))
 	}
-	return errorText
+	return common.RedactText(errorText)
 }
 
 // ID contains the subject identifier related to the error
@@ -227,32 +386,74 @@ func (e *cerror) Nested() error {
 	return nil
 }
 
-// RecommendedActions steps that a user can perform to correct the error condition
+// Unwrap returns the nested error so the standard library errors.Is and errors.As can walk the error chain
+func (e *cerror) Unwrap() error {
+	return e.Nested()
+}
+
+// Is reports whether the target is a core.Error with the same code. If the target has an id it must also match,
+// so a sentinel core.Error with an empty id matches any core.Error with the same code
+func (e *cerror) Is(target error) bool {
+	if e == nil {
+		return false
+	}
+	t, ok := target.(Error)
+	if !ok {
+		return false
+	}
+	if e.Code() != t.Code() {
+		return false
+	}
+	return len(t.ID()) == 0 || e.id == t.ID()
+}
+
+// As sets target to this error if target is a pointer to a core.Error
+func (e *cerror) As(target interface{}) bool {
+	if e == nil {
+		return false
+	}
+	switch t := target.(type) {
+	case *Error:
+		*t = e
+		return true
+	case **cerror:
+		*t = e
+		return true
+	}
+	return false
+}
+
+// RecommendedActions returns a copy of the steps that a user can perform to correct the error condition
 func (e *cerror) RecommendedActions() []string {
 	if e != nil {
-		return e.recommendedActions
+		e.lock.RLock()
+		defer e.lock.RUnlock()
+		if e.recommendedActions == nil {
+			return nil
+		}
+		return append([]string{}, e.recommendedActions...)
 	}
 	return nil
 }
 
 // MakeError creates a core.Error
 func MakeError(id string, code int, msg string) error {
-	return makeError(id, code, msg, common.GetCaller(4, true))
+	return addStack(makeError(id, code, msg, common.GetCaller(4, true)), StackTracesEnabled())
 }
 
 // MakeErrorAt creates a core.Error, setting the function and file/line to the value provided
 func MakeErrorAt(id string, code int, msg, where string) error {
-	return makeError(id, code, msg, where)
+	return addStack(makeError(id, code, msg, where), StackTracesEnabled())
 }
 
 // RaiseError creates a core.Error from a nested error
 func RaiseError(id string, code int, msg string, nested interface{}) error {
-	return raiseError(id, code, msg, common.GetCaller(4, true), nested)
+	return addStack(raiseError(id, code, msg, common.GetCaller(4, true), nested), StackTracesEnabled())
 }
 
 // RaiseErrorAt creates a core.Error from a nested error and file/line to the value provided
 func RaiseErrorAt(id string, code int, msg, where string, nested interface{}) error {
-	return raiseError(id, code, msg, where, nested)
+	return addStack(raiseError(id, code, msg, where, nested), StackTracesEnabled())
 }
 
 // makeError creates a core.Error
@@ -274,25 +475,33 @@ func makeError(id string, code int, msg, where string) error {
 func raiseError(id string, code int, msg, where string, nested interface{}) error {
 	// Check if nested error is of type core.Error and return a core.Error
 	if nestedCoreError, ok := nested.(*cerror); ok {
-		err := makeError(id, nestedCoreError.code, msg, where)
+		err := makeError(id, nestedCoreError.Code(), msg, where)
 		if err != nil {
 			if cerr, ok := err.(*cerror); ok {
 				cerr.addNested(nestedCoreError)
+				// Inherit the context fields and request identifiers of the nested error
+				cerr.fields = nestedCoreError.fields.merge(nil)
+				cerr.request = nestedCoreError.request
 				return cerr
 			}
 		}
 	}
 
-	var errMsg string
-
-	// The nested error is probably a standard error
+	// The nested error is probably a standard error, keep it so callers can inspect it
 	if nestedErr, ok := nested.(error); ok {
-		errMsg = fmt.Sprintf("%s, %s", msg, nestedErr)
-	} else {
-		// At this point the input parameter called nested is not something that implements std error.
-		// So let fmt do it's magic
-		errMsg = fmt.Sprintf("%s, %v", msg, nested)
+		err := makeError(id, code, msg, where)
+		if cerr, ok := err.(*cerror); ok {
+			cerr.addNested(nestedErr)
+			// The nested error text may allow the code to be set
+			if e := cerr.SetCode(); e != nil {
+				return nil
+			}
+			return cerr
+		}
+		return err
 	}
 
-	return makeError(id, code, errMsg, where)
+	// At this point the input parameter called nested is not something that implements std error.
+	// So let fmt do it's magic
+	return makeError(id, code, fmt.Sprintf("%s, %v", msg, nested), where)
 }
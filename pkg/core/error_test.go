@@ -3,7 +3,9 @@
 package core
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
 	"testing"
 
 	"github.com/paulcarlton/go-utils/pkg/testutils"
@@ -29,7 +31,6 @@ func TestCaller(t *testing.T) {
 	}
 
 	err := fmt.Errorf("%s", "standard error")
-	msg = fmt.Sprintf("RaiseError return, %s", err.Error())
 	testFunc = func() error {
 		return RaiseError("test1", ErrorBadRequest, "RaiseError return", err)
 	}
@@ -38,8 +39,9 @@ func TestCaller(t *testing.T) {
 		id:                 "test1",
 		where:              "core.TestCaller.func2() - error_test.go(NN)",
 		code:               ErrorBadRequest,
-		message:            msg,
+		message:            "RaiseError return",
 		recommendedActions: []string{},
+		nestedError:        err,
 	}
 	newErr = testFunc()
 	if !CompareErrors(newErr.(Error), expected) || testutils.FailTests {
@@ -109,9 +111,9 @@ func TestRaiseErrorAt(t *testing.T) {
 			expected: &cerror{
 				id:                 "test1",
 				code:               ErrorBadRequest,
-				message:            "raising a Error here because a call generated, an instance of a standard error type",
+				message:            "raising a Error here because a call generated",
 				where:              "somewhere() - something.go (NN)",
-				nestedError:        nil,
+				nestedError:        fmt.Errorf("%s", "an instance of a standard error type"),
 				recommendedActions: []string{},
 			},
 		},
@@ -127,9 +129,9 @@ func TestRaiseErrorAt(t *testing.T) {
 			expected: &cerror{
 				id:                 "test1",
 				code:               ErrorUnauthorized,
-				message:            "failed to so something, something about a permission error etc",
+				message:            "failed to so something",
 				where:              "somewhere() - something.go (NN)",
-				nestedError:        nil,
+				nestedError:        fmt.Errorf("%s", "something about a permission error etc"),
 				recommendedActions: []string{},
 			},
 		},
@@ -199,8 +201,8 @@ func TestRaiseError(t *testing.T) {
 				id:                 "test1",
 				where:              "core.TestRaiseError() - error_test.go(NN)",
 				code:               ErrorBadRequest,
-				message:            "raising a Error here because a call generated, an instance of a standard error type",
-				nestedError:        nil,
+				message:            "raising a Error here because a call generated",
+				nestedError:        fmt.Errorf("%s", "an instance of a standard error type"),
 				recommendedActions: []string{},
 			},
 		},
@@ -216,8 +218,8 @@ func TestRaiseError(t *testing.T) {
 				id:                 "test1",
 				where:              "core.TestRaiseError() - error_test.go(NN)",
 				code:               ErrorUnauthorized,
-				message:            "failed to so something, something about a permission error etc",
-				nestedError:        nil,
+				message:            "failed to so something",
+				nestedError:        fmt.Errorf("%s", "something about a permission error etc"),
 				recommendedActions: []string{},
 			},
 		},
@@ -734,3 +736,123 @@ func TestErrorSetMessage(t *testing.T) {
 		}
 	}
 }
+
+func TestErrorUnwrap(t *testing.T) {
+	stdErr := fmt.Errorf("%s", "an instance of a standard error type")
+	nested := &cerror{
+		id:          "test1",
+		where:       "core.TestErrorUnwrap() - error_test.go(NN)",
+		code:        ErrorNotFound,
+		message:     "item not found",
+		nestedError: stdErr,
+	}
+	var tests = []struct {
+		testNum  int
+		coreErr  *cerror
+		expected error
+	}{
+		{
+			testNum: 1,
+			coreErr: &cerror{
+				id:          "test1",
+				where:       "core.TestErrorUnwrap() - error_test.go(NN)",
+				code:        ErrorBadRequest,
+				message:     msg,
+				nestedError: nested,
+			},
+			expected: nested,
+		},
+		{
+			testNum:  2,
+			coreErr:  nested,
+			expected: stdErr,
+		},
+		{
+			testNum:  3,
+			coreErr:  &cerror{id: "test1", code: ErrorBadRequest, message: msg},
+			expected: nil,
+		},
+		{
+			testNum:  4,
+			coreErr:  nil,
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		result := test.coreErr.Unwrap()
+		if result != test.expected || testutils.FailTests {
+			t.Errorf("\nTest: %d\nInput1..:\n%+v\nExpected:\n%v\nGot.....:\n%v",
+				test.testNum, test.coreErr, test.expected, result)
+		}
+	}
+}
+
+func TestErrorIs(t *testing.T) {
+	stdErr := fmt.Errorf("%s", "an instance of a standard error type")
+	notFound := MakeError("", ErrorNotFound, "not found")
+	notFoundTest1 := MakeError("test1", ErrorNotFound, "not found")
+	chain := RaiseError("outer", ErrorBadRequest, "failed to get item",
+		RaiseErrorAt("test1", ErrorNotFound, "item missing", "somewhere() - something.go (NN)",
+			&cerror{id: "test1", code: ErrorNotFound, message: "lookup failed", nestedError: stdErr}))
+	var tests = []struct {
+		testNum  int
+		err      error
+		target   error
+		expected bool
+	}{
+		{testNum: 1, err: chain, target: notFound, expected: true},
+		{testNum: 2, err: chain, target: notFoundTest1, expected: true},
+		{testNum: 3, err: chain, target: MakeError("test2", ErrorNotFound, "not found"), expected: false},
+		{testNum: 4, err: chain, target: MakeError("", ErrorDuplicateEntry, "conflict"), expected: false},
+		{testNum: 5, err: chain, target: stdErr, expected: true},
+		{testNum: 6, err: stdErr, target: notFound, expected: false},
+		{testNum: 7, err: MakeError("", ErrorBadRequest, msg), target: notFound, expected: false},
+	}
+
+	for _, test := range tests {
+		result := errors.Is(test.err, test.target)
+		if result != test.expected || testutils.FailTests {
+			t.Errorf("\nTest: %d\nInput1..:\n%s\nTarget..:\n%s\nExpected:\n%t\nGot.....:\n%t",
+				test.testNum, ErrorText(test.err), ErrorText(test.target), test.expected, result)
+		}
+	}
+}
+
+func TestErrorAs(t *testing.T) {
+	stdErr := fmt.Errorf("%s", "an instance of a standard error type")
+	coreErr := &cerror{id: "test1", code: ErrorNotFound, message: "lookup failed"}
+	var tests = []struct {
+		testNum  int
+		err      error
+		expected Error
+	}{
+		{testNum: 1, err: coreErr, expected: coreErr},
+		{testNum: 2, err: fmt.Errorf("wrapped: %w", coreErr), expected: coreErr},
+		{testNum: 3, err: stdErr, expected: nil},
+	}
+
+	for _, test := range tests {
+		var result Error
+		ok := errors.As(test.err, &result)
+		if ok != (test.expected != nil) || (ok && result != test.expected) || testutils.FailTests {
+			t.Errorf("\nTest: %d\nInput1..:\n%s\nExpected:\n%v\nGot.....:\n%v",
+				test.testNum, ErrorText(test.err), test.expected, result)
+		}
+	}
+}
+
+func TestRaiseErrorKeepsNested(t *testing.T) {
+	_, urlErr := url.Parse("http://[::1")
+	err := RaiseError("test1", ErrorInvalidInput, "failed to parse uri", urlErr)
+
+	var nested *url.Error
+	if !errors.As(err, &nested) || nested != urlErr || testutils.FailTests {
+		t.Errorf("\nTest: 1\nExpected nested error:\n%#v\nGot.....:\n%#v", urlErr, err.(Error).Nested())
+	}
+
+	expected := fmt.Sprintf("%s\nNested Errors...\n%s", err.Error(), urlErr)
+	if err.(Error).FullInfo() != expected || testutils.FailTests {
+		t.Errorf("\nTest: 2\nExpected:\n%s\nGot.....:\n%s", expected, err.(Error).FullInfo())
+	}
+}
@@ -0,0 +1,296 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+// Package corecheck provides an analyzer that checks the use of the core.Error constructors.
+// It reports constructors called with an empty id, MakeError called with core.ErrorUnknown, messages
+// formatted using fmt.Sprintf with values that may hold secrets, RaiseError called with a nil nested error
+// and errors returned from the methods of location.Handler implementations that are not a core.Error.
+package corecheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"regexp"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/paulcarlton/go-utils/pkg/internal/common"
+)
+
+const (
+	// CorePackage is the import path of the core package
+	CorePackage = "github.com/paulcarlton/go-utils/pkg/core"
+	// LocationPackage is the import path of the package defining the Handler interface
+	LocationPackage = "github.com/paulcarlton/go-utils/pkg/location"
+
+	name = "corecheck"
+
+	doc = `check the use of the core.Error constructors
+
+The corecheck analyzer reports:
+- core.Error constructor calls, such as core.MakeError, core.NotFoundf and core.RaiseReasonError, with an empty id
+- core.MakeError calls with core.ErrorUnknown rather than a specific code
+- messages formatted using fmt.Sprintf, or by the formatted constructors, with values that may hold secrets
+- core.RaiseError calls with a nil nested error
+- errors returned from location.Handler methods that are not wrapped in a core.Error`
+)
+
+// Analyzer checks the use of the core.Error constructors
+var Analyzer = &analysis.Analyzer{
+	Name:     name,
+	Doc:      doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// constructor holds the positions of the arguments of a core.Error constructor, code is -1 if the constructor
+// sets the code, msg is -1 if it has no message and nested is -1 if it has no nested error. If format is set the
+// message is a format string followed by its arguments.
+type constructor struct {
+	id, code, msg, nested int
+	format                bool
+}
+
+// constructors are the core.Error constructors checked, by name
+var constructors = map[string]constructor{
+	"MakeError":           {id: 0, code: 1, msg: 2, nested: -1},
+	"MakeErrorAt":         {id: 0, code: 1, msg: 2, nested: -1},
+	"MakeErrorWithStack":  {id: 0, code: 1, msg: 2, nested: -1},
+	"MakeErrorCtx":        {id: 1, code: 2, msg: 3, nested: -1},
+	"RaiseError":          {id: 0, code: 1, msg: 2, nested: 3},
+	"RaiseErrorAt":        {id: 0, code: 1, msg: 2, nested: 4},
+	"RaiseErrorWithStack": {id: 0, code: 1, msg: 2, nested: 3},
+	"RaiseErrorCtx":       {id: 1, code: 2, msg: 3, nested: 4},
+	"MakeReasonError":     {id: 0, code: -1, msg: 2, nested: -1},
+	"RaiseReasonError":    {id: 0, code: -1, msg: 2, nested: 3},
+	"MakeCatalogError":    {id: 0, code: 1, msg: -1, nested: -1},
+	"RaiseCatalogError":   {id: 0, code: 1, msg: -1, nested: 4},
+	"NewErrorList":        {id: 0, code: -1, msg: 1, nested: -1},
+	"BadRequestf":         {id: 0, code: -1, msg: 1, nested: -1, format: true},
+	"Conflictf":           {id: 0, code: -1, msg: 1, nested: -1, format: true},
+	"Internalf":           {id: 0, code: -1, msg: 1, nested: -1, format: true},
+	"InvalidInputf":       {id: 0, code: -1, msg: 1, nested: -1, format: true},
+	"NotFoundf":           {id: 0, code: -1, msg: 1, nested: -1, format: true},
+	"NotAllowedf":         {id: 0, code: -1, msg: 1, nested: -1, format: true},
+	"Unauthorizedf":       {id: 0, code: -1, msg: 1, nested: -1, format: true},
+	"Unavailablef":        {id: 0, code: -1, msg: 1, nested: -1, format: true},
+	"NotImplementedf":     {id: 0, code: -1, msg: 1, nested: -1, format: true},
+}
+
+// args returns the number of arguments a call to the constructor must have
+func (c constructor) args() int {
+	count := c.id
+	for _, index := range []int{c.code, c.msg, c.nested} {
+		if index > count {
+			count = index
+		}
+	}
+	return count + 1
+}
+
+// formatKey matches the key of a 'key: %v' or 'key=%v' pair in a format string
+var formatKey = regexp.MustCompile(`([A-Za-z0-9_.-]+)\s*[:=]\s*%`)
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	handler := lookupInterface(pass.Pkg, LocationPackage, "Handler")
+	coreError := lookupInterface(pass.Pkg, CorePackage, "Error")
+
+	nodes := []ast.Node{(*ast.CallExpr)(nil), (*ast.FuncDecl)(nil)}
+	inspect.Preorder(nodes, func(node ast.Node) {
+		switch n := node.(type) {
+		case *ast.CallExpr:
+			checkConstructor(pass, n)
+		case *ast.FuncDecl:
+			if isHandlerMethod(pass, n, handler) {
+				checkReturns(pass, n, coreError)
+			}
+		}
+	})
+	return nil, nil
+}
+
+// checkConstructor reports incorrect arguments passed to a core.Error constructor
+func checkConstructor(pass *analysis.Pass, call *ast.CallExpr) {
+	fn := calledFunc(pass, call, CorePackage)
+	if fn == nil || fn.Type().(*types.Signature).Recv() != nil {
+		return
+	}
+	c, ok := constructors[fn.Name()]
+	if !ok || len(call.Args) < c.args() {
+		return
+	}
+
+	if value := pass.TypesInfo.Types[call.Args[c.id]].Value; value != nil && value.Kind() == constant.String &&
+		len(constant.StringVal(value)) == 0 {
+		pass.Reportf(call.Args[c.id].Pos(), "core.%s called with an empty id", fn.Name())
+	}
+	if c.code >= 0 && c.nested < 0 && isObject(pass, call.Args[c.code], CorePackage, "ErrorUnknown") {
+		pass.Reportf(call.Args[c.code].Pos(), "core.%s called with core.ErrorUnknown, use a specific code", fn.Name())
+	}
+	if c.msg >= 0 {
+		name, ok := sensitiveFormat(pass, call.Args[c.msg])
+		if c.format {
+			name, ok = sensitiveArgs(pass, call.Args[c.msg], call.Args[c.msg+1:])
+		}
+		if ok {
+			pass.Reportf(call.Args[c.msg].Pos(), "message of core.%s is formatted with %s which may hold a secret", fn.Name(), name)
+		}
+	}
+	if c.nested >= 0 && pass.TypesInfo.Types[call.Args[c.nested]].IsNil() {
+		pass.Reportf(call.Args[c.nested].Pos(), "core.%s called with a nil nested error, use core.%s", fn.Name(),
+			strings.Replace(fn.Name(), "Raise", "Make", 1))
+	}
+}
+
+// sensitiveFormat returns the key or argument of a fmt.Sprintf call that may hold a secret
+func sensitiveFormat(pass *analysis.Pass, expr ast.Expr) (string, bool) {
+	call, ok := astutil.Unparen(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) == 0 {
+		return "", false
+	}
+	if fn := calledFunc(pass, call, "fmt"); fn == nil || fn.Name() != "Sprintf" {
+		return "", false
+	}
+	return sensitiveArgs(pass, call.Args[0], call.Args[1:])
+}
+
+// sensitiveArgs returns the key in a format string or the argument formatted that may hold a secret
+func sensitiveArgs(pass *analysis.Pass, format ast.Expr, args []ast.Expr) (string, bool) {
+	if value := pass.TypesInfo.Types[format].Value; value != nil && value.Kind() == constant.String {
+		for _, match := range formatKey.FindAllStringSubmatch(constant.StringVal(value), -1) {
+			if common.IsSensitiveKey(match[1]) {
+				return match[1], true
+			}
+		}
+	}
+	for _, arg := range args {
+		if name := exprName(arg); len(name) > 0 && common.IsSensitiveKey(name) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// checkReturns reports errors returned from a function that are not nil or a core.Error
+func checkReturns(pass *analysis.Pass, decl *ast.FuncDecl, coreError *types.Interface) {
+	results := pass.TypesInfo.Defs[decl.Name].Type().(*types.Signature).Results()
+	errorIndex := -1
+	for index := 0; index < results.Len(); index++ {
+		if types.Identical(results.At(index).Type(), types.Universe.Lookup("error").Type()) {
+			errorIndex = index
+		}
+	}
+	if errorIndex < 0 || decl.Body == nil {
+		return
+	}
+
+	ast.Inspect(decl.Body, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if len(n.Results) != results.Len() {
+				return true
+			}
+			result := n.Results[errorIndex]
+			if !pass.TypesInfo.Types[result].IsNil() && !isCoreError(pass, result, coreError) {
+				pass.Reportf(result.Pos(), "error returned from handler method %s is not wrapped in a core.Error", decl.Name.Name)
+			}
+		}
+		return true
+	})
+}
+
+// isCoreError checks if an expression is a core.Error or is the result of a call to a core function or method
+func isCoreError(pass *analysis.Pass, expr ast.Expr, coreError *types.Interface) bool {
+	if call, ok := astutil.Unparen(expr).(*ast.CallExpr); ok && calledFunc(pass, call, CorePackage) != nil {
+		return true
+	}
+	exprType := pass.TypesInfo.TypeOf(expr)
+	return coreError != nil && exprType != nil && types.Implements(exprType, coreError)
+}
+
+// isHandlerMethod checks if a function is a method of the handler interface implemented by its receiver
+func isHandlerMethod(pass *analysis.Pass, decl *ast.FuncDecl, handler *types.Interface) bool {
+	if handler == nil || decl.Recv == nil {
+		return false
+	}
+	fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+	if !ok {
+		return false
+	}
+	recv := fn.Type().(*types.Signature).Recv().Type()
+	if _, pointer := recv.(*types.Pointer); !pointer {
+		recv = types.NewPointer(recv)
+	}
+	if !types.Implements(recv, handler) {
+		return false
+	}
+	for index := 0; index < handler.NumMethods(); index++ {
+		if handler.Method(index).Name() == fn.Name() {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupInterface returns an interface declared in a package, if it is the package being analyzed or one it imports
+func lookupInterface(pkg *types.Package, path, name string) *types.Interface {
+	candidates := append([]*types.Package{pkg}, pkg.Imports()...)
+	for _, candidate := range candidates {
+		if candidate.Path() != path {
+			continue
+		}
+		if obj, ok := candidate.Scope().Lookup(name).(*types.TypeName); ok {
+			if iface, ok := obj.Type().Underlying().(*types.Interface); ok {
+				return iface
+			}
+		}
+	}
+	return nil
+}
+
+// calledFunc returns the function or method called if it is declared in a package
+func calledFunc(pass *analysis.Pass, call *ast.CallExpr, path string) *types.Func {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != path {
+		return nil
+	}
+	return fn
+}
+
+// isObject checks if an expression refers to an object declared in a package
+func isObject(pass *analysis.Pass, expr ast.Expr, path, name string) bool {
+	var ident *ast.Ident
+	switch e := astutil.Unparen(expr).(type) {
+	case *ast.Ident:
+		ident = e
+	case *ast.SelectorExpr:
+		ident = e.Sel
+	default:
+		return false
+	}
+	obj := pass.TypesInfo.Uses[ident]
+	return obj != nil && obj.Pkg() != nil && obj.Pkg().Path() == path && obj.Name() == name
+}
+
+// exprName returns the name of the variable, field or method an expression refers to
+func exprName(expr ast.Expr) string {
+	switch e := astutil.Unparen(expr).(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	case *ast.CallExpr:
+		return exprName(e.Fun)
+	case *ast.StarExpr:
+		return exprName(e.X)
+	}
+	return ""
+}
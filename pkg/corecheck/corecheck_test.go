@@ -0,0 +1,13 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package corecheck
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "example")
+}
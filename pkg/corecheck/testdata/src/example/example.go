@@ -0,0 +1,68 @@
+package example
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/paulcarlton/go-utils/pkg/core"
+	"github.com/paulcarlton/go-utils/pkg/location"
+)
+
+var _ location.Handler = &handler{}
+
+type handler struct {
+	password string
+}
+
+func constructors(name, token string, err error) {
+	_ = core.MakeError("", core.ErrorNotFound, "not found")                             // want `core.MakeError called with an empty id`
+	_ = core.MakeError("example", core.ErrorUnknown, "failed")                          // want `core.MakeError called with core.ErrorUnknown, use a specific code`
+	_ = core.RaiseError("example", core.ErrorUnknown, "failed", err)                    // ErrorUnknown takes the code of the nested error
+	_ = core.MakeError("example", core.ErrorNotFound, fmt.Sprintf("user %s", name))     // no secret in the message
+	_ = core.MakeError("example", core.ErrorNotFound, fmt.Sprintf("token %s", token))   // want `message of core.MakeError is formatted with token which may hold a secret`
+	_ = core.MakeError("example", core.ErrorNotFound, fmt.Sprintf("password=%s", name)) // want `message of core.MakeError is formatted with password which may hold a secret`
+	_ = core.RaiseError("example", core.ErrorNotFound, "failed", nil)                   // want `core.RaiseError called with a nil nested error, use core.MakeError`
+	_ = core.RaiseErrorAt("example", core.ErrorNotFound, "failed", "here", nil)         // want `core.RaiseErrorAt called with a nil nested error, use core.MakeErrorAt`
+}
+
+func otherConstructors(name, token string, err error) {
+	_ = core.NotFoundf("", "no such item %s", name)                       // want `core.NotFoundf called with an empty id`
+	_ = core.NotFoundf("example", "no such item %s", name)                // no secret in the message
+	_ = core.NotFoundf("example", "no such item, token: %s", name)        // want `message of core.NotFoundf is formatted with token which may hold a secret`
+	_ = core.NotFoundf("example", "no such item %s", token)               // want `message of core.NotFoundf is formatted with token which may hold a secret`
+	_ = core.MakeReasonError("", "example.not-found", "")                 // want `core.MakeReasonError called with an empty id`
+	_ = core.RaiseReasonError("example", "example.not-found", "", nil)    // want `core.RaiseReasonError called with a nil nested error, use core.MakeReasonError`
+	_ = core.MakeCatalogError("", core.ErrorNotFound, "example.key", nil) // want `core.MakeCatalogError called with an empty id`
+	_ = core.RaiseCatalogError("example", core.ErrorUnknown, "example.key", nil, err)
+	_ = core.RaiseCatalogError("example", core.ErrorNotFound, "example.key", nil, nil) // want `core.RaiseCatalogError called with a nil nested error, use core.MakeCatalogError`
+	_ = core.NewErrorList("", "failed")                                                // want `core.NewErrorList called with an empty id`
+}
+
+func (h *handler) GetData(uri string) (interface{}, error) {
+	if len(uri) == 0 {
+		return nil, errors.New("empty uri") // want `error returned from handler method GetData is not wrapped in a core.Error`
+	}
+	if len(uri) == 1 {
+		return nil, core.WithFields(core.RaiseError("example", core.ErrorNotFound, "failed", errors.New("short")), nil)
+	}
+	if err := h.check(uri); err != nil {
+		return nil, err // want `error returned from handler method GetData is not wrapped in a core.Error`
+	}
+	var coreErr core.Error
+	if coreErr != nil {
+		return nil, coreErr
+	}
+	return h.password, nil
+}
+
+func (h *handler) DeleteData(uri string) error {
+	check := func() error {
+		return errors.New("not a handler method")
+	}
+	return check() // want `error returned from handler method DeleteData is not wrapped in a core.Error`
+}
+
+// check is not a handler method so its errors are not reported
+func (h *handler) check(uri string) error {
+	return errors.New("failed")
+}
@@ -0,0 +1,61 @@
+// Package core is a stub of the core package used by the corecheck tests
+package core
+
+import "fmt"
+
+// Error is a stub of core.Error
+type Error interface {
+	error
+	Code() int
+}
+
+type cerror struct{ code int }
+
+func (e *cerror) Error() string { return fmt.Sprint(e.code) }
+func (e *cerror) Code() int     { return e.code }
+
+// Error codes
+const (
+	ErrorUnknown  = 466
+	ErrorNotFound = 404
+)
+
+// MakeError is a stub of core.MakeError
+func MakeError(id string, code int, msg string) error { return &cerror{code: code} }
+
+// RaiseError is a stub of core.RaiseError
+func RaiseError(id string, code int, msg string, nested interface{}) error {
+	return &cerror{code: code}
+}
+
+// RaiseErrorAt is a stub of core.RaiseErrorAt
+func RaiseErrorAt(id string, code int, msg, where string, nested interface{}) error {
+	return &cerror{code: code}
+}
+
+// NotFoundf is a stub of core.NotFoundf
+func NotFoundf(id, format string, args ...interface{}) error { return &cerror{code: ErrorNotFound} }
+
+// MakeReasonError is a stub of core.MakeReasonError
+func MakeReasonError(id, reason, msg string) error { return &cerror{code: ErrorNotFound} }
+
+// RaiseReasonError is a stub of core.RaiseReasonError
+func RaiseReasonError(id, reason, msg string, nested interface{}) error {
+	return &cerror{code: ErrorNotFound}
+}
+
+// MakeCatalogError is a stub of core.MakeCatalogError
+func MakeCatalogError(id string, code int, key string, args map[string]interface{}) error {
+	return &cerror{code: code}
+}
+
+// RaiseCatalogError is a stub of core.RaiseCatalogError
+func RaiseCatalogError(id string, code int, key string, args map[string]interface{}, nested interface{}) error {
+	return &cerror{code: code}
+}
+
+// NewErrorList is a stub of core.NewErrorList
+func NewErrorList(id, msg string) error { return &cerror{code: ErrorUnknown} }
+
+// WithFields is a stub of core.WithFields
+func WithFields(err error, fields map[string]interface{}) error { return err }
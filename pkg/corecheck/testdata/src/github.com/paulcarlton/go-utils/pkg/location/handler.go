@@ -0,0 +1,8 @@
+// Package location is a stub of the location package used by the corecheck tests
+package location
+
+// Handler is a stub of location.Handler
+type Handler interface {
+	GetData(string) (interface{}, error)
+	DeleteData(string) error
+}
@@ -0,0 +1,464 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+// Package errorcatalog finds the call sites of the core.Error constructors in Go source code and documents
+// the id, code, message and recommended actions of each error, grouped by package.
+package errorcatalog
+
+import (
+	"bytes"
+	"go/ast"
+	"go/constant"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/paulcarlton/go-utils/pkg/core"
+)
+
+const (
+	// CorePackage is the import path of the core package
+	CorePackage = "github.com/paulcarlton/go-utils/pkg/core"
+
+	id string = "errorcatalog"
+)
+
+type (
+	// Entry describes a call site of a core.Error constructor
+	Entry struct {
+		Function           string   `json:"function"`
+		ID                 string   `json:"id"`
+		Code               int      `json:"code,omitempty"`
+		CodeName           string   `json:"codeName"`
+		CodeText           string   `json:"codeText,omitempty"`
+		Message            string   `json:"message"`
+		RecommendedActions []string `json:"recommendedActions,omitempty"`
+		File               string   `json:"file"`
+		Line               int      `json:"line"`
+	}
+
+	// Package holds the entries of the call sites in a package directory
+	Package struct {
+		Path   string  `json:"package"`
+		Name   string  `json:"name"`
+		Errors []Entry `json:"errors"`
+	}
+
+	// parsedPackage holds the parsed non test files of a package directory
+	parsedPackage struct {
+		dir   string
+		name  string
+		fset  *token.FileSet
+		files []*ast.File
+	}
+)
+
+// constructor holds the positions of the id, code and message arguments of a core.Error constructor
+// If the constructor sets the code, code is -1 and codeName is the name of the code it sets
+type constructor struct {
+	id, code, msg int
+	codeName      string
+}
+
+// constructors are the core.Error constructors documented
+var constructors = map[string]constructor{
+	"MakeError":           {id: 0, code: 1, msg: 2},
+	"MakeErrorAt":         {id: 0, code: 1, msg: 2},
+	"MakeErrorWithStack":  {id: 0, code: 1, msg: 2},
+	"MakeErrorCtx":        {id: 1, code: 2, msg: 3},
+	"RaiseError":          {id: 0, code: 1, msg: 2},
+	"RaiseErrorAt":        {id: 0, code: 1, msg: 2},
+	"RaiseErrorWithStack": {id: 0, code: 1, msg: 2},
+	"RaiseErrorCtx":       {id: 1, code: 2, msg: 3},
+	"BadRequestf":         {id: 0, code: -1, msg: 1, codeName: "ErrorBadRequest"},
+	"Conflictf":           {id: 0, code: -1, msg: 1, codeName: "ErrorDuplicateEntry"},
+	"Internalf":           {id: 0, code: -1, msg: 1, codeName: "ErrorInternal"},
+	"InvalidInputf":       {id: 0, code: -1, msg: 1, codeName: "ErrorInvalidInput"},
+	"NotFoundf":           {id: 0, code: -1, msg: 1, codeName: "ErrorNotFound"},
+	"NotAllowedf":         {id: 0, code: -1, msg: 1, codeName: "ErrorNotAllowed"},
+	"Unauthorizedf":       {id: 0, code: -1, msg: 1, codeName: "ErrorUnauthorized"},
+	"Unavailablef":        {id: 0, code: -1, msg: 1, codeName: "ErrorServiceUnavailable"},
+	"NotImplementedf":     {id: 0, code: -1, msg: 1, codeName: "ErrorNotImplemented"},
+}
+
+// args returns the number of arguments a call to the constructor must have
+func (c constructor) args() int {
+	count := c.id
+	for _, index := range []int{c.code, c.msg} {
+		if index > count {
+			count = index
+		}
+	}
+	return count + 1
+}
+
+// codes maps the names of the core error code constants to their values
+var codes = map[string]int{
+	"ErrorUnknown":            core.ErrorUnknown,
+	"ErrorBadRequest":         core.ErrorBadRequest,
+	"ErrorDuplicateEntry":     core.ErrorDuplicateEntry,
+	"ErrorInternal":           core.ErrorInternal,
+	"ErrorInvalidInput":       core.ErrorInvalidInput,
+	"ErrorNotFound":           core.ErrorNotFound,
+	"ErrorNotAllowed":         core.ErrorNotAllowed,
+	"ErrorUnauthorized":       core.ErrorUnauthorized,
+	"ErrorServiceUnavailable": core.ErrorServiceUnavailable,
+	"ErrorNotImplemented":     core.ErrorNotImplemented,
+}
+
+// Scan parses the Go packages in directories and returns the call sites of the core.Error constructors
+// A directory ending in '/...' includes its sub directories. Test files, testdata and vendor directories
+// are skipped. Messages that are string constants declared in one of the packages scanned are resolved.
+func Scan(patterns ...string) ([]Package, error) {
+	dirs, err := expandPatterns(patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := []*parsedPackage{}
+	for _, dir := range dirs {
+		pkg, err := parseDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		if pkg != nil {
+			parsed = append(parsed, pkg)
+		}
+	}
+
+	constants := stringConstants(parsed)
+	result := []Package{}
+	for _, pkg := range parsed {
+		entries := pkg.entries(constants)
+		if len(entries) > 0 {
+			result = append(result, Package{Path: filepath.ToSlash(pkg.dir), Name: pkg.name, Errors: entries})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Path < result[j].Path })
+	return result, nil
+}
+
+// expandPatterns returns the directories matching patterns, walking the sub directories of those ending in '/...'
+func expandPatterns(patterns []string) ([]string, error) {
+	dirs := []string{}
+	for _, pattern := range patterns {
+		if !strings.HasSuffix(pattern, "...") {
+			dirs = append(dirs, filepath.Clean(pattern))
+			continue
+		}
+		root := filepath.Clean(strings.TrimSuffix(strings.TrimSuffix(pattern, "..."), "/"))
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() {
+				return nil
+			}
+			if name := info.Name(); path != root && (name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+			dirs = append(dirs, path)
+			return nil
+		})
+		if err != nil {
+			return nil, core.RaiseError(id, core.ErrorInvalidInput, "failed to walk "+root, err)
+		}
+	}
+	return dirs, nil
+}
+
+// parseDir parses the non test files of the package in a directory, it returns nil if there are none
+func parseDir(dir string) (*parsedPackage, error) {
+	fset := token.NewFileSet()
+	notTest := func(info os.FileInfo) bool { return !strings.HasSuffix(info.Name(), "_test.go") }
+	pkgs, err := parser.ParseDir(fset, dir, notTest, 0)
+	if err != nil {
+		return nil, core.RaiseError(id, core.ErrorInvalidInput, "failed to parse "+dir, err)
+	}
+	for name, pkg := range pkgs {
+		if strings.HasSuffix(name, "_test") {
+			continue
+		}
+		result := &parsedPackage{dir: dir, name: name, fset: fset}
+		for _, file := range pkg.Files {
+			result.files = append(result.files, file)
+		}
+		sort.Slice(result.files, func(i, j int) bool {
+			return fset.Position(result.files[i].Pos()).Filename < fset.Position(result.files[j].Pos()).Filename
+		})
+		return result, nil
+	}
+	return nil, nil
+}
+
+// stringConstants returns the values of the package level string constants of the packages, keyed by
+// package name and constant name, e.g. location.ErrorStringURIParseFail
+func stringConstants(pkgs []*parsedPackage) map[string]string {
+	constants := map[string]string{}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.CONST {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					value := spec.(*ast.ValueSpec)
+					for index, name := range value.Names {
+						if index < len(value.Values) {
+							if text, ok := stringLiteral(value.Values[index]); ok {
+								constants[pkg.name+"."+name.Name] = text
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	return constants
+}
+
+// entries returns the call sites of the core.Error constructors in a package
+func (pkg *parsedPackage) entries(constants map[string]string) []Entry {
+	entries := []Entry{}
+	for _, file := range pkg.files {
+		coreName := importName(file, CorePackage)
+		if len(coreName) == 0 && pkg.name != "core" {
+			continue
+		}
+		actions := recommendedActions(file, pkg, constants)
+		ast.Inspect(file, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			function := constructorName(call, coreName, pkg.name == "core")
+			c := constructors[function]
+			if len(function) == 0 || len(call.Args) < c.args() {
+				return true
+			}
+			position := pkg.fset.Position(call.Pos())
+			entry := Entry{
+				Function:           function,
+				ID:                 pkg.text(call.Args[c.id], constants),
+				Message:            pkg.message(call.Args[c.msg], constants),
+				RecommendedActions: actions[call],
+				File:               filepath.Base(position.Filename),
+				Line:               position.Line,
+			}
+			if c.code < 0 {
+				entry.Code, entry.CodeName = codes[c.codeName], c.codeName
+			} else {
+				entry.Code, entry.CodeName = pkg.code(call.Args[c.code], coreName)
+			}
+			if entry.Code > 0 {
+				entry.CodeText = core.CodeText(entry.Code)
+			}
+			entries = append(entries, entry)
+			return true
+		})
+	}
+	return entries
+}
+
+// constructorName returns the name of the core.Error constructor called or an empty string
+func constructorName(call *ast.CallExpr, coreName string, inCore bool) string {
+	switch fun := call.Fun.(type) {
+	case *ast.SelectorExpr:
+		if x, ok := fun.X.(*ast.Ident); ok && len(coreName) > 0 && x.Name == coreName {
+			if _, ok := constructors[fun.Sel.Name]; ok {
+				return fun.Sel.Name
+			}
+		}
+	case *ast.Ident:
+		if _, ok := constructors[fun.Name]; ok && inCore {
+			return fun.Name
+		}
+	}
+	return ""
+}
+
+// recommendedActions returns the recommended actions added to the errors created by constructor calls in a file
+// Actions are found in calls to WithRecommendedActions chained to the constructor call and in calls to
+// AddRecommendedActions or WithRecommendedActions on the variable the error is assigned to, later in the same block
+func recommendedActions(file *ast.File, pkg *parsedPackage, constants map[string]string) map[*ast.CallExpr][]string {
+	actions := map[*ast.CallExpr][]string{}
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.CallExpr:
+			if receiver, ok := actionsReceiver(n); ok {
+				if call, ok := unwrap(receiver).(*ast.CallExpr); ok {
+					actions[call] = append(actions[call], pkg.texts(n.Args, constants)...)
+				}
+			}
+		case *ast.BlockStmt:
+			for index, stmt := range n.List {
+				name, call := assignedCall(stmt)
+				if call == nil {
+					continue
+				}
+				for _, later := range n.List[index+1:] {
+					ast.Inspect(later, func(node ast.Node) bool {
+						if c, ok := node.(*ast.CallExpr); ok {
+							if receiver, ok := actionsReceiver(c); ok {
+								if ident, ok := unwrap(receiver).(*ast.Ident); ok && ident.Name == name {
+									actions[call] = append(actions[call], pkg.texts(c.Args, constants)...)
+								}
+							}
+						}
+						return true
+					})
+				}
+			}
+		}
+		return true
+	})
+	return actions
+}
+
+// actionsReceiver returns the receiver of a call to AddRecommendedActions or WithRecommendedActions
+func actionsReceiver(call *ast.CallExpr) (ast.Expr, bool) {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || (selector.Sel.Name != "AddRecommendedActions" && selector.Sel.Name != "WithRecommendedActions") {
+		return nil, false
+	}
+	return selector.X, true
+}
+
+// assignedCall returns the name of the variable and the call of a statement assigning the result of a call
+func assignedCall(stmt ast.Stmt) (string, *ast.CallExpr) {
+	assign, ok := stmt.(*ast.AssignStmt)
+	if !ok || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return "", nil
+	}
+	ident, ok := assign.Lhs[0].(*ast.Ident)
+	if !ok {
+		return "", nil
+	}
+	call, ok := unwrap(assign.Rhs[0]).(*ast.CallExpr)
+	if !ok {
+		return "", nil
+	}
+	return ident.Name, call
+}
+
+// unwrap removes the parentheses and type assertions around an expression
+func unwrap(expr ast.Expr) ast.Expr {
+	for {
+		switch e := expr.(type) {
+		case *ast.ParenExpr:
+			expr = e.X
+		case *ast.TypeAssertExpr:
+			expr = e.X
+		default:
+			return expr
+		}
+	}
+}
+
+// importName returns the name a file imports a package as or an empty string if it is not imported
+func importName(file *ast.File, path string) string {
+	for _, spec := range file.Imports {
+		if importPath, err := strconv.Unquote(spec.Path.Value); err == nil && importPath == path {
+			if spec.Name != nil {
+				return spec.Name.Name
+			}
+			return path[strings.LastIndex(path, "/")+1:]
+		}
+	}
+	return ""
+}
+
+// code returns the value and name of the code passed to a constructor, the value is 0 if it is not known
+func (pkg *parsedPackage) code(expr ast.Expr, coreName string) (int, string) {
+	switch e := expr.(type) {
+	case *ast.SelectorExpr:
+		if x, ok := e.X.(*ast.Ident); ok && x.Name == coreName {
+			return codes[e.Sel.Name], e.Sel.Name
+		}
+	case *ast.Ident:
+		if value, ok := codes[e.Name]; ok && pkg.name == "core" {
+			return value, e.Name
+		}
+	case *ast.BasicLit:
+		if value, err := strconv.Atoi(e.Value); err == nil {
+			return value, e.Value
+		}
+	}
+	return 0, pkg.source(expr)
+}
+
+// message returns the message passed to a constructor, the format of a fmt.Sprintf call is used as the message
+func (pkg *parsedPackage) message(expr ast.Expr, constants map[string]string) string {
+	if call, ok := expr.(*ast.CallExpr); ok && len(call.Args) > 0 {
+		if selector, ok := call.Fun.(*ast.SelectorExpr); ok && selector.Sel.Name == "Sprintf" {
+			if x, ok := selector.X.(*ast.Ident); ok && x.Name == "fmt" {
+				return pkg.text(call.Args[0], constants)
+			}
+		}
+	}
+	return pkg.text(expr, constants)
+}
+
+// texts returns the text of expressions
+func (pkg *parsedPackage) texts(exprs []ast.Expr, constants map[string]string) []string {
+	texts := make([]string, 0, len(exprs))
+	for _, expr := range exprs {
+		texts = append(texts, pkg.text(expr, constants))
+	}
+	return texts
+}
+
+// text returns the value of a string literal or constant, or the source of other expressions
+func (pkg *parsedPackage) text(expr ast.Expr, constants map[string]string) string {
+	if text, ok := stringLiteral(expr); ok {
+		return text
+	}
+	key := ""
+	switch e := expr.(type) {
+	case *ast.Ident:
+		key = pkg.name + "." + e.Name
+	case *ast.SelectorExpr:
+		if x, ok := e.X.(*ast.Ident); ok {
+			key = x.Name + "." + e.Sel.Name
+		}
+	}
+	if text, ok := constants[key]; ok {
+		return text
+	}
+	return pkg.source(expr)
+}
+
+// source returns the source code of an expression
+func (pkg *parsedPackage) source(expr ast.Expr) string {
+	var buf bytes.Buffer
+	if err := format.Node(&buf, pkg.fset, expr); err != nil {
+		return ""
+	}
+	return buf.String()
+}
+
+// stringLiteral returns the value of a string literal or a concatenation of string literals
+func stringLiteral(expr ast.Expr) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind == token.STRING {
+			if value := constant.MakeFromLiteral(e.Value, e.Kind, 0); value.Kind() == constant.String {
+				return constant.StringVal(value), true
+			}
+		}
+	case *ast.BinaryExpr:
+		if e.Op == token.ADD {
+			left, leftOK := stringLiteral(e.X)
+			right, rightOK := stringLiteral(e.Y)
+			return left + right, leftOK && rightOK
+		}
+	case *ast.ParenExpr:
+		return stringLiteral(e.X)
+	}
+	return "", false
+}
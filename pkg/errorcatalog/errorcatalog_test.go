@@ -0,0 +1,96 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package errorcatalog
+
+import (
+	"testing"
+
+	"github.com/paulcarlton/go-utils/pkg/core"
+	"github.com/paulcarlton/go-utils/pkg/testutils"
+)
+
+func TestScan(t *testing.T) {
+	var tests = []struct {
+		testNum  int
+		patterns []string
+		expected []Package
+	}{
+		{
+			testNum:  1,
+			patterns: []string{"testdata/example"},
+			expected: []Package{
+				{
+					Path: "testdata/example",
+					Name: "example",
+					Errors: []Entry{
+						{
+							Function: "RaiseError",
+							ID:       "example",
+							Code:     core.ErrorNotFound,
+							CodeName: "ErrorNotFound",
+							CodeText: "Not Found",
+							Message:  "item is missing",
+							File:     "example.go",
+							Line:     15,
+						},
+						{
+							Function:           "MakeError",
+							ID:                 "name",
+							Code:               core.ErrorNotFound,
+							CodeName:           "ErrorNotFound",
+							CodeText:           "Not Found",
+							Message:            "item %s not found",
+							RecommendedActions: []string{"create the item", "check the name"},
+							File:               "example.go",
+							Line:               17,
+						},
+						{
+							Function:           "MakeErrorAt",
+							ID:                 "example",
+							CodeName:           "http.StatusTooManyRequests",
+							Message:            "slow down",
+							RecommendedActions: []string{"retry later"},
+							File:               "example.go",
+							Line:               22,
+						},
+						{
+							Function: "MakeErrorCtx",
+							ID:       "example",
+							Code:     core.ErrorInvalidInput,
+							CodeName: "ErrorInvalidInput",
+							CodeText: "Unprocessable Entity",
+							Message:  "name is empty",
+							File:     "example.go",
+							Line:     28,
+						},
+						{
+							Function: "NotFoundf",
+							ID:       "example",
+							Code:     core.ErrorNotFound,
+							CodeName: "ErrorNotFound",
+							CodeText: "Not Found",
+							Message:  "item %s not found",
+							File:     "example.go",
+							Line:     30,
+						},
+					},
+				},
+			},
+		},
+		{testNum: 2, patterns: []string{"testdata/..."}},
+	}
+
+	for _, test := range tests {
+		if test.expected == nil {
+			test.expected = tests[0].expected
+		}
+		result, err := Scan(test.patterns...)
+		if err != nil || !testutils.CompareItems(result, test.expected) || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%+v\nGot.....:\n%+v\n%v", test.testNum, test.expected, result, err)
+		}
+	}
+
+	if _, err := Scan("testdata/missing"); err == nil || testutils.FailTests {
+		t.Errorf("\nTest: 3\nExpected:\nerror\nGot.....:\n%v", err)
+	}
+}
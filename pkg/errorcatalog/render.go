@@ -0,0 +1,56 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package errorcatalog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/paulcarlton/go-utils/pkg/core"
+)
+
+// WriteJSON writes the catalog of packages as indented json
+func WriteJSON(w io.Writer, pkgs []Package) error {
+	data, err := json.MarshalIndent(pkgs, "", "  ")
+	if err != nil {
+		return core.RaiseError(id, core.ErrorInternal, "failed to marshal error catalog", err)
+	}
+	if _, err := fmt.Fprintf(w, "%s\n", data); err != nil {
+		return core.RaiseError(id, core.ErrorInternal, "failed to write error catalog", err)
+	}
+	return nil
+}
+
+// WriteMarkdown writes the catalog of packages as a Markdown document with a table of errors per package
+func WriteMarkdown(w io.Writer, pkgs []Package) error {
+	text := "# Error Catalog\n"
+	for _, pkg := range pkgs {
+		text += fmt.Sprintf("\n## %s\n\n", pkg.Path)
+		text += "| ID | Code | Message | Recommended Actions | Location |\n"
+		text += "| --- | --- | --- | --- | --- |\n"
+		for _, entry := range pkg.Errors {
+			text += fmt.Sprintf("| %s | %s | %s | %s | %s(%d) |\n", cell(entry.ID), cell(entry.codeCell()),
+				cell(entry.Message), cell(strings.Join(entry.RecommendedActions, "<br>")), entry.File, entry.Line)
+		}
+	}
+	if _, err := io.WriteString(w, text); err != nil {
+		return core.RaiseError(id, core.ErrorInternal, "failed to write error catalog", err)
+	}
+	return nil
+}
+
+// codeCell returns the text of the code of an entry, the value and text if the code is known or its name
+func (e Entry) codeCell() string {
+	if e.Code == 0 {
+		return e.CodeName
+	}
+	return fmt.Sprintf("%d %s", e.Code, e.CodeText)
+}
+
+// cell escapes text for use in a Markdown table cell
+func cell(text string) string {
+	text = strings.Replace(text, "|", "\\|", -1)
+	return strings.Replace(text, "\n", " ", -1)
+}
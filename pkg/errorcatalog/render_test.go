@@ -0,0 +1,92 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package errorcatalog
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/paulcarlton/go-utils/pkg/testutils"
+)
+
+var testPackages = []Package{
+	{
+		Path: "pkg/example",
+		Name: "example",
+		Errors: []Entry{
+			{
+				Function:           "MakeError",
+				ID:                 "example",
+				Code:               404,
+				CodeName:           "ErrorNotFound",
+				CodeText:           "Not Found",
+				Message:            "item | name not found",
+				RecommendedActions: []string{"create the item", "check the name"},
+				File:               "example.go",
+				Line:               16,
+			},
+			{
+				Function: "MakeErrorAt",
+				ID:       "example",
+				CodeName: "http.StatusTooManyRequests",
+				Message:  "slow down",
+				File:     "example.go",
+				Line:     21,
+			},
+		},
+	},
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	expected := `# Error Catalog
+
+## pkg/example
+
+| ID | Code | Message | Recommended Actions | Location |
+| --- | --- | --- | --- | --- |
+| example | 404 Not Found | item \| name not found | create the item<br>check the name | example.go(16) |
+| example | http.StatusTooManyRequests | slow down |  | example.go(21) |
+`
+	var buf bytes.Buffer
+	if err := WriteMarkdown(&buf, testPackages); err != nil || buf.String() != expected || testutils.FailTests {
+		t.Errorf("\nTest: 1\nExpected:\n%s\nGot.....:\n%s\n%v", expected, buf.String(), err)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	expected := `[
+  {
+    "package": "pkg/example",
+    "name": "example",
+    "errors": [
+      {
+        "function": "MakeError",
+        "id": "example",
+        "code": 404,
+        "codeName": "ErrorNotFound",
+        "codeText": "Not Found",
+        "message": "item | name not found",
+        "recommendedActions": [
+          "create the item",
+          "check the name"
+        ],
+        "file": "example.go",
+        "line": 16
+      },
+      {
+        "function": "MakeErrorAt",
+        "id": "example",
+        "codeName": "http.StatusTooManyRequests",
+        "message": "slow down",
+        "file": "example.go",
+        "line": 21
+      }
+    ]
+  }
+]
+`
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, testPackages); err != nil || buf.String() != expected || testutils.FailTests {
+		t.Errorf("\nTest: 1\nExpected:\n%s\nGot.....:\n%s\n%v", expected, buf.String(), err)
+	}
+}
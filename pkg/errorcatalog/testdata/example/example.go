@@ -0,0 +1,31 @@
+package example
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	coreerrors "github.com/paulcarlton/go-utils/pkg/core"
+)
+
+const errorStringMissing = "item is missing"
+
+func find(name string, err error) error {
+	if err != nil {
+		return coreerrors.RaiseError("example", coreerrors.ErrorNotFound, errorStringMissing, err)
+	}
+	missing := coreerrors.MakeError(name, coreerrors.ErrorNotFound, fmt.Sprintf("item %s not found", name))
+	missing.(coreerrors.Error).AddRecommendedActions("create the item", "check the "+"name")
+	if len(name) > 10 {
+		return missing
+	}
+	return coreerrors.MakeErrorAt("example", http.StatusTooManyRequests, "slow down", "here").(coreerrors.Error).
+		WithRecommendedActions("retry later")
+}
+
+func lookup(ctx context.Context, name string) error {
+	if len(name) == 0 {
+		return coreerrors.MakeErrorCtx(ctx, "example", coreerrors.ErrorInvalidInput, "name is empty")
+	}
+	return coreerrors.NotFoundf("example", "item %s not found", name)
+}
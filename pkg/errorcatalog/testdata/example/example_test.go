@@ -0,0 +1,5 @@
+package example
+
+import coreerrors "github.com/paulcarlton/go-utils/pkg/core"
+
+var testError = coreerrors.MakeError("test", coreerrors.ErrorInternal, "test files are skipped")
@@ -0,0 +1,40 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package goutils
+
+import (
+	"net/http"
+
+	"github.com/paulcarlton/go-utils/pkg/internal/common"
+)
+
+// DumpOptions control the details included by RequestDump and ResponseDump
+type DumpOptions = common.DumpOptions
+
+// DefaultDumpOptions returns options that include the headers and up to 4KB of the body, indenting json bodies
+func DefaultDumpOptions() DumpOptions {
+	return common.DefaultDumpOptions()
+}
+
+// RequestDump generates a string containing the method, url, protocol, TLS connection state, headers and body
+// of a request. Sensitive query parameters, headers and body values are redacted unless redaction is disabled.
+// No more of the body than the options include is read, and the body is replaced so it can be read again.
+func RequestDump(r *http.Request, opts DumpOptions) string {
+	return common.RequestDump(r, opts)
+}
+
+// ResponseDump generates a string containing the status, protocol, request, TLS connection state, headers and
+// body of a response. Sensitive query parameters, headers and body values are redacted unless redaction is
+// disabled. No more of the body than the options include is read, and the body is replaced so it can be read again.
+func ResponseDump(resp *http.Response, opts DumpOptions) string {
+	return common.ResponseDump(resp, opts)
+}
+
+// ToCurl generates a curl command line that repeats a request, including its headers and body
+// Sensitive query parameters and headers are redacted unless redaction is disabled, so disable redaction
+// to get a command line that can be replayed. A body longer than the default dump options include is
+// omitted, with a comment saying so, since a truncated body would not repeat the request. No more of the
+// body than that is read and the body is replaced so it can be read again.
+func ToCurl(r *http.Request) string {
+	return common.ToCurl(r)
+}
@@ -3,8 +3,11 @@
 package goutils
 
 import (
+	"context"
 	"net/http"
+	"time"
 
+	"github.com/paulcarlton/go-utils/pkg/backoff"
 	"github.com/paulcarlton/go-utils/pkg/core"
 	"github.com/paulcarlton/go-utils/pkg/internal/common"
 )
@@ -33,6 +36,18 @@ func GetCaller(skip uint, short bool) string {
 	return common.GetCaller(skip+1, short)
 }
 
+// Frame describes a function call in the call stack, see CallersFrames
+type Frame = common.Frame
+
+// FrameOptions control which frames CallersFrames returns, they can skip runtime, testing and vendor frames,
+// trim a module prefix from the package and file and stop at a boundary function such as testing.tRunner
+type FrameOptions = common.FrameOptions
+
+// CallersFrames returns the frames of the call stack, starting with the caller of CallersFrames 'skip' levels back
+func CallersFrames(skip uint, opts FrameOptions) []Frame {
+	return common.CallersFrames(skip+1, opts)
+}
+
 // ToJSON is used to convert a data structure into JSON format.
 func ToJSON(data interface{}) (string, error) {
 	return common.ToJSON(data)
@@ -67,10 +82,19 @@ func PrettyJSON(data string) (string, error) {
 	return common.PrettyJSON(data)
 }
 
+// sleepFunc is the function ExponentialDelay sleeps with, replaced by tests
+var sleepFunc = backoff.Sleep
+
 // ExponentialDelay sleeps for wait time seconds
 // it returns the wait time multiplied by 2 or 1 if the new wait is greater than max
 // The intended usage is to pass a wait time and use the returned value in the next call
 // This causes increasing waits up to max
+// Deprecated: use a backoff.Exponential policy, which supports durations and cancellation
 func ExponentialDelay(waitTime, max uint) uint {
-	return common.ExponentialDelay(waitTime, max)
+	sleepFunc(context.Background(), time.Duration(waitTime)*time.Second) // nolint: errcheck
+	waitTime += waitTime
+	if waitTime > max {
+		waitTime = 1
+	}
+	return waitTime
 }
@@ -3,11 +3,14 @@
 package goutils
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/paulcarlton/go-utils/pkg/backoff"
 	"github.com/paulcarlton/go-utils/pkg/core"
 	"github.com/paulcarlton/go-utils/pkg/testutils"
 )
@@ -84,6 +87,32 @@ func TestGetCaller(t *testing.T) {
 	}
 }
 
+func TestCallersFrames(t *testing.T) {
+	var tests = []struct {
+		testNum  int
+		skip     uint
+		opts     FrameOptions
+		expected []string
+	}{
+		{testNum: 1, skip: 0, opts: FrameOptions{Boundary: "testing.tRunner"},
+			expected: []string{"goutils.TestCallersFrames() - misc_utils_test.go(NN)"}},
+		{testNum: 2, skip: 0, opts: FrameOptions{SkipRuntime: true, SkipTesting: true},
+			expected: []string{"goutils.TestCallersFrames() - misc_utils_test.go(NN)"}},
+		{testNum: 3, skip: 1, opts: FrameOptions{Boundary: "testing.tRunner"}, expected: []string{}},
+	}
+
+	for _, test := range tests {
+		result := []string{}
+		for _, frame := range CallersFrames(test.skip, test.opts) {
+			result = append(result, frame.Short())
+		}
+		if !testutils.CompareWhereList(result, test.expected) || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%s\nGot.....:\n%s", test.testNum, testutils.DisplayStrings(test.expected),
+				testutils.DisplayStrings(result))
+		}
+	}
+}
+
 func TestToJSON(t *testing.T) {
 	type TestInfo struct {
 		object   interface{}
@@ -266,3 +295,33 @@ func TestPrettyJSON(t *testing.T) {
 		}
 	}
 }
+
+func TestExponentialDelay(t *testing.T) {
+	var slept time.Duration
+	sleepFunc = func(ctx context.Context, delay time.Duration) error {
+		slept = delay
+		return nil
+	}
+	defer func() { sleepFunc = backoff.Sleep }()
+
+	var tests = []struct {
+		testNum  int
+		waitTime uint
+		max      uint
+		expected uint
+	}{
+		{testNum: 1, waitTime: 1, max: 10, expected: 2},
+		{testNum: 2, waitTime: 4, max: 10, expected: 8},
+		{testNum: 3, waitTime: 5, max: 10, expected: 10},
+		{testNum: 4, waitTime: 8, max: 10, expected: 1},
+		{testNum: 5, waitTime: 3, max: 0, expected: 1},
+	}
+
+	for _, test := range tests {
+		result := ExponentialDelay(test.waitTime, test.max)
+		if result != test.expected || slept != time.Duration(test.waitTime)*time.Second || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%d, slept %ds\nGot.....:\n%d, slept %s",
+				test.testNum, test.expected, test.waitTime, result, slept)
+		}
+	}
+}
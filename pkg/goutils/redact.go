@@ -0,0 +1,45 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package goutils
+
+import (
+	"github.com/paulcarlton/go-utils/pkg/core"
+	"github.com/paulcarlton/go-utils/pkg/internal/common"
+)
+
+const (
+	// RedactedText replaces sensitive values in rendered output
+	RedactedText = common.RedactedText
+	// SensitiveTag is the struct tag that marks a field as sensitive, for example `sensitive:"true"`
+	SensitiveTag = common.SensitiveTag
+	// RedactionEnvVar is the environment variable that disables redaction when set to "off"
+	RedactionEnvVar = common.RedactionEnvVar
+)
+
+// EnableRedaction turns redaction of sensitive values by the renderers in core and goutils on or off
+// Redaction is on by default, turning it off is intended for local debugging only
+func EnableRedaction(enabled bool) {
+	common.EnableRedaction(enabled)
+}
+
+// RedactionEnabled reports whether sensitive values are redacted
+func RedactionEnabled() bool {
+	return common.RedactionEnabled()
+}
+
+// RegisterSensitiveKey adds a regular expression matching keys, field names, headers and query parameters
+// whose values are redacted. The expression must match the whole key and matching is case insensitive. Keys
+// ending in password, token, api key or private key and authorization headers are registered by default.
+func RegisterSensitiveKey(pattern string) error {
+	if err := common.RegisterSensitiveKey(pattern); err != nil {
+		return core.RaiseError(pattern, core.ErrorInvalidInput, "failed to register sensitive key", err)
+	}
+	return nil
+}
+
+// RedactText replaces the values of sensitive 'key: value' and 'key=value' pairs and the credentials of
+// authorization header values in text. A 'key: value' pair must start the text or a clause so prose
+// containing colons is left unchanged.
+func RedactText(text string) string {
+	return common.RedactText(text)
+}
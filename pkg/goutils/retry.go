@@ -0,0 +1,76 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package goutils
+
+import (
+	"context"
+	"time"
+
+	"github.com/paulcarlton/go-utils/pkg/backoff"
+	"github.com/paulcarlton/go-utils/pkg/core"
+)
+
+const (
+	// retryID is the id of the errors created by Retry
+	retryID = "retry"
+
+	// DefaultRetryDelay is the delay used by a RetryPolicy with no Backoff and a Delay of zero
+	DefaultRetryDelay = 100 * time.Millisecond
+)
+
+// RetryPolicy controls the number of attempts Retry makes and the delay between them
+// If Backoff is set it provides the delays, otherwise the delay starts at Delay, or DefaultRetryDelay if Delay is
+// zero, and doubles after each attempt up to MaxDelay, if MaxDelay is zero the delay does not increase. If MaxAttempts is zero attempts are made
+// until the operation succeeds, fails with a permanent error, the backoff policy is exhausted or the context is done.
+type RetryPolicy struct {
+	MaxAttempts uint
+	Delay       time.Duration
+	MaxDelay    time.Duration
+	Backoff     backoff.Policy
+}
+
+// Retry calls fn until it succeeds, returns an error that is not retryable according to core.IsRetryable,
+// the attempts allowed by the policy are used or the context is done. If it gives up it returns a
+// core.ErrorList containing the error from each attempt, with the attempt number added as a field. Errors
+// that are not core errors are wrapped in a core.Error to hold the field.
+func Retry(ctx context.Context, policy RetryPolicy, fn func(ctx context.Context) error) error {
+	attempts := core.NewErrorList(retryID, "operation failed, retries abandoned")
+	delays := policy.backoff()
+	delays.Reset()
+	for attempt := uint(1); ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+		attemptErr := err
+		if _, ok := err.(core.Error); !ok {
+			attemptErr = core.RaiseError(retryID, core.ErrorUnknown, "attempt failed", err)
+		}
+		attempts.Append(core.WithFields(attemptErr, core.Fields{"attempt": attempt}))
+
+		if core.IsPermanent(err) || (policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts) {
+			return attempts
+		}
+
+		if err := delays.Wait(ctx); err != nil {
+			attempts.Append(err)
+			return attempts
+		}
+	}
+}
+
+// backoff returns the backoff policy of a RetryPolicy
+func (policy RetryPolicy) backoff() backoff.Policy {
+	if policy.Backoff != nil {
+		return policy.Backoff
+	}
+	delay := policy.Delay
+	if delay == 0 {
+		delay = DefaultRetryDelay
+	}
+	multiplier := backoff.DefaultMultiplier
+	if policy.MaxDelay == 0 {
+		multiplier = 1
+	}
+	return backoff.NewExponential(delay, policy.MaxDelay, multiplier, 0)
+}
@@ -0,0 +1,106 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package goutils
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/paulcarlton/go-utils/pkg/backoff"
+	"github.com/paulcarlton/go-utils/pkg/core"
+	"github.com/paulcarlton/go-utils/pkg/testutils"
+)
+
+func TestRetry(t *testing.T) {
+	unavailable := core.MakeError("test", core.ErrorServiceUnavailable, "service unavailable")
+	notFound := core.MakeError("test", core.ErrorNotFound, "not found")
+
+	var tests = []struct {
+		testNum  int
+		policy   RetryPolicy
+		errs     []error
+		attempts int
+		expected int
+	}{
+		{testNum: 1, policy: RetryPolicy{MaxAttempts: 3, Delay: time.Millisecond}, errs: []error{nil}, attempts: 1, expected: 0},
+		{testNum: 2, policy: RetryPolicy{MaxAttempts: 3, Delay: time.Millisecond}, errs: []error{unavailable, nil}, attempts: 2, expected: 0},
+		{testNum: 3, policy: RetryPolicy{MaxAttempts: 3, Delay: time.Millisecond, MaxDelay: 2 * time.Millisecond},
+			errs: []error{unavailable, unavailable, unavailable, nil}, attempts: 3, expected: 3},
+		{testNum: 4, policy: RetryPolicy{Delay: time.Millisecond}, errs: []error{unavailable, notFound, nil}, attempts: 2, expected: 2},
+		{testNum: 5, policy: RetryPolicy{}, errs: []error{errors.New("failed"), nil}, attempts: 1, expected: 1},
+		{testNum: 6, policy: RetryPolicy{}, errs: []error{core.MakeError("test", core.ErrorInternal, "failed").(core.Error).WithRetryable(true), nil},
+			attempts: 2, expected: 0},
+		{testNum: 7, policy: RetryPolicy{Backoff: backoff.NewConstant(time.Millisecond, 0)}, errs: []error{unavailable, unavailable, nil},
+			attempts: 3, expected: 0},
+		{testNum: 8, policy: RetryPolicy{Backoff: backoff.NewConstant(time.Hour, time.Minute)}, errs: []error{unavailable, nil},
+			attempts: 1, expected: 2},
+	}
+
+	for _, test := range tests {
+		attempts := 0
+		err := Retry(context.Background(), test.policy, func(ctx context.Context) error {
+			attempts++
+			return test.errs[attempts-1]
+		})
+		errCount := 0
+		if list, ok := err.(*core.ErrorList); ok {
+			errCount = list.Len()
+		}
+		if attempts != test.attempts || errCount != test.expected || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%d attempts, %d errors\nGot.....:\n%d attempts, %s",
+				test.testNum, test.attempts, test.expected, attempts, core.ErrorText(err))
+		}
+	}
+}
+
+func TestRetryAttemptFields(t *testing.T) {
+	err := Retry(context.Background(), RetryPolicy{MaxAttempts: 2}, func(ctx context.Context) error {
+		return core.MakeError("test", http.StatusTooManyRequests, "too many requests")
+	})
+	list, ok := err.(*core.ErrorList)
+	if !ok || list.Len() != 2 || list.Errors()[1].(core.Error).Fields()["attempt"] != uint(2) || testutils.FailTests {
+		t.Errorf("\nTest: 1\nExpected:\n2 errors with attempt fields\nGot.....:\n%s", core.ErrorText(err))
+	}
+
+	plainErr := errors.New("failed")
+	err = Retry(context.Background(), RetryPolicy{}, func(ctx context.Context) error {
+		return plainErr
+	})
+	list, ok = err.(*core.ErrorList)
+	if !ok || list.Len() != 1 || list.Errors()[0].(core.Error).Fields()["attempt"] != uint(1) ||
+		!errors.Is(err, plainErr) || list.ID() != retryID || testutils.FailTests {
+		t.Errorf("\nTest: 2\nExpected:\nplain error wrapped with attempt field\nGot.....:\n%s", core.ErrorText(err))
+	}
+}
+
+func TestRetryCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	err := Retry(ctx, RetryPolicy{Delay: time.Hour}, func(ctx context.Context) error {
+		cancel()
+		return core.MakeError("test", core.ErrorServiceUnavailable, "service unavailable")
+	})
+	list, ok := err.(*core.ErrorList)
+	if !ok || list.Len() != 2 || !errors.Is(err, context.Canceled) || !core.IsPermanent(err) || testutils.FailTests {
+		t.Errorf("\nTest: 1\nExpected:\nretry cancelled\nGot.....:\n%s", core.ErrorText(err))
+	}
+}
+
+func TestRetryZeroPolicy(t *testing.T) {
+	if delay, ok := (RetryPolicy{}).backoff().Next(); !ok || delay != DefaultRetryDelay || testutils.FailTests {
+		t.Errorf("\nTest: 1\nExpected:\n%s\nGot.....:\n%s", DefaultRetryDelay, delay)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*DefaultRetryDelay-DefaultRetryDelay/2)
+	defer cancel()
+	attempts := 0
+	err := Retry(ctx, RetryPolicy{}, func(ctx context.Context) error {
+		attempts++
+		return core.MakeError("test", core.ErrorServiceUnavailable, "service unavailable")
+	})
+	if attempts < 2 || attempts > 3 || !errors.Is(err, context.DeadlineExceeded) || testutils.FailTests {
+		t.Errorf("\nTest: 2\nExpected:\n2 or 3 attempts\nGot.....:\n%d attempts, %s", attempts, core.ErrorText(err))
+	}
+}
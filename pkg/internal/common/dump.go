@@ -0,0 +1,256 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package common
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// DumpOptions control the details included by RequestDump and ResponseDump
+type DumpOptions struct {
+	// Headers includes the headers, the values of sensitive headers are redacted
+	Headers bool
+	// MaxBody is the maximum number of bytes of the body included, 0 includes all of it and -1 omits it
+	MaxBody int
+	// Pretty indents json bodies using PrettyJSON
+	Pretty bool
+}
+
+// DefaultDumpOptions returns options that include the headers and up to 4KB of the body, indenting json bodies
+func DefaultDumpOptions() DumpOptions {
+	return DumpOptions{Headers: true, MaxBody: 4096, Pretty: true}
+}
+
+// readCloser reads the data read from a body followed by the rest of the body and closes the body
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// sensitiveHeaders are the headers whose values are redacted in addition to those matching a sensitive key
+var sensitiveHeaders = []string{"Cookie", "Set-Cookie"}
+
+// jsonPairText matches '"key": value' pairs in json text that cannot be decoded, such as a truncated body
+var jsonPairText = regexp.MustCompile(`"([^"\\]+)"(\s*:\s*)("(?:[^"\\]|\\.)*"?|[^\s,}\]]+)`)
+
+// tlsVersions holds the names of the TLS versions
+var tlsVersions = map[uint16]string{
+	tls.VersionTLS10: "TLS 1.0",
+	tls.VersionTLS11: "TLS 1.1",
+	tls.VersionTLS12: "TLS 1.2",
+	tls.VersionTLS13: "TLS 1.3",
+}
+
+// RequestDump generates a string containing the method, url, protocol, TLS connection state, headers and body
+// of a request. Sensitive query parameters, headers and body values are redacted unless redaction is disabled.
+// No more of the body than the options include is read, and the body is replaced so it can be read again.
+func RequestDump(r *http.Request, opts DumpOptions) string {
+	if r == nil {
+		return "Request: nil\n"
+	}
+	dumpText := fmt.Sprintf("Request: %s %s\nProto: %s\n", r.Method, RedactURL(requestURL(r)), r.Proto)
+	dumpText += tlsText(r.TLS)
+	return dumpText + dumpMessage(r.Header, &r.Body, r.ContentLength, opts)
+}
+
+// ResponseDump generates a string containing the status, protocol, request, TLS connection state, headers and
+// body of a response. Sensitive query parameters, headers and body values are redacted unless redaction is
+// disabled. No more of the body than the options include is read, and the body is replaced so it can be read again.
+func ResponseDump(resp *http.Response, opts DumpOptions) string {
+	if resp == nil {
+		return "Response: nil\n"
+	}
+	dumpText := fmt.Sprintf("Response: %s\nProto: %s\n", resp.Status, resp.Proto)
+	if resp.Request != nil {
+		dumpText += fmt.Sprintf("Request: %s %s\n", resp.Request.Method, RedactURL(requestURL(resp.Request)))
+	}
+	dumpText += tlsText(resp.TLS)
+	return dumpText + dumpMessage(resp.Header, &resp.Body, resp.ContentLength, opts)
+}
+
+// ToCurl generates a curl command line that repeats a request, including its headers and body
+// Sensitive query parameters and headers are redacted unless redaction is disabled, so disable redaction
+// to get a command line that can be replayed. A body longer than the default dump options include is
+// omitted, with a comment saying so, since a truncated body would not repeat the request. No more of the
+// body than that is read and the body is replaced so it can be read again.
+func ToCurl(r *http.Request) string {
+	if r == nil {
+		return ""
+	}
+	u := requestURL(r)
+	args := []string{"curl"}
+	switch r.Method {
+	case "", http.MethodGet:
+	case http.MethodHead:
+		args = append(args, "-I")
+	default:
+		args = append(args, "-X", r.Method)
+	}
+	args = append(args, shellQuote(RedactURL(u)))
+	if len(r.Host) > 0 && r.Host != u.Host {
+		args = append(args, "-H", shellQuote("Host: "+r.Host))
+	}
+	for _, header := range headerLines(r.Header) {
+		args = append(args, "-H", shellQuote(header))
+	}
+	maxBody := DefaultDumpOptions().MaxBody
+	body, err := readBody(&r.Body, maxBody)
+	if err != nil {
+		return fmt.Sprintf("error reading body, %s", err)
+	}
+	if len(body) > maxBody {
+		args = append(args, fmt.Sprintf("# body of more than %d bytes omitted", maxBody))
+	} else if len(body) > 0 {
+		args = append(args, "--data-binary", shellQuote(RedactBody(r.Header.Get("Content-Type"), body)))
+	}
+	return strings.Join(args, " ")
+}
+
+// dumpMessage returns the text of the headers and body of a request or response
+func dumpMessage(header http.Header, body *io.ReadCloser, contentLength int64, opts DumpOptions) string {
+	dumpText := ""
+	if opts.Headers && len(header) > 0 {
+		dumpText += fmt.Sprintf("Headers..\n%s\n", strings.Join(headerLines(header), "\n"))
+	}
+	if opts.MaxBody < 0 {
+		return dumpText
+	}
+	data, err := readBody(body, opts.MaxBody)
+	if err != nil {
+		return dumpText + fmt.Sprintf("error reading body, %s\n", err)
+	}
+	if len(data) > 0 {
+		dumpText += fmt.Sprintf("Body..\n%s\n", bodyText(header.Get("Content-Type"), data, contentLength, opts))
+	}
+	return dumpText
+}
+
+// bodyText returns the text of a body, truncated, redacted and formatted according to its content type.
+// The data holds one byte more than the maximum body size if the body is longer
+func bodyText(contentType string, data []byte, contentLength int64, opts DumpOptions) string {
+	truncated := opts.MaxBody > 0 && len(data) > opts.MaxBody
+	if truncated {
+		length := opts.MaxBody
+		for length > 0 && !utf8.RuneStart(data[length]) {
+			length--
+		}
+		data = data[:length]
+	}
+	if !utf8.Valid(data) {
+		return fmt.Sprintf("[%d bytes of binary data]", len(data))
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	isJSON := strings.HasSuffix(mediaType, "json")
+	text := RedactBody(contentType, data)
+	if truncated && isJSON {
+		text = redactJSONText(text)
+	}
+	if opts.Pretty && isJSON && !truncated {
+		if pretty, err := PrettyJSON(text); err == nil {
+			text = pretty
+		}
+	}
+	if !truncated {
+		return text
+	}
+	if contentLength > 0 {
+		return fmt.Sprintf("%s\n[truncated, %d of %d bytes shown]", text, len(data), contentLength)
+	}
+	return fmt.Sprintf("%s\n[truncated, %d bytes shown]", text, len(data))
+}
+
+// redactJSONText replaces the values of sensitive keys in json text that cannot be decoded
+func redactJSONText(text string) string {
+	if !RedactionEnabled() {
+		return text
+	}
+	return jsonPairText.ReplaceAllStringFunc(text, func(pair string) string {
+		parts := jsonPairText.FindStringSubmatch(pair)
+		if !IsSensitiveKey(parts[1]) {
+			return pair
+		}
+		return fmt.Sprintf(`"%s"%s"%s"`, parts[1], parts[2], RedactedText)
+	})
+}
+
+// headerLines returns the headers as sorted 'Key: value' lines with the values of sensitive headers redacted
+func headerLines(header http.Header) []string {
+	keys := make([]string, 0, len(header))
+	for key := range header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	lines := []string{}
+	for _, key := range keys {
+		for _, value := range header[key] {
+			if RedactionEnabled() && FindInStringSlice(sensitiveHeaders, http.CanonicalHeaderKey(key)) >= 0 {
+				value = RedactedText
+			}
+			lines = append(lines, fmt.Sprintf("%s: %v", key, RedactValue(key, value)))
+		}
+	}
+	return lines
+}
+
+// tlsText returns the text of the TLS connection state of a request or response, or an empty string if there is none
+func tlsText(state *tls.ConnectionState) string {
+	if state == nil {
+		return ""
+	}
+	version, ok := tlsVersions[state.Version]
+	if !ok {
+		version = fmt.Sprintf("0x%04x", state.Version)
+	}
+	return fmt.Sprintf("TLS: %s, cipher suite %s, server name %s, protocol %s\n", version,
+		tls.CipherSuiteName(state.CipherSuite), state.ServerName, state.NegotiatedProtocol)
+}
+
+// requestURL returns the url of a request, adding the scheme and host of requests received by a server
+func requestURL(r *http.Request) *url.URL {
+	if r.URL == nil {
+		return &url.URL{Host: r.Host}
+	}
+	if len(r.URL.Host) > 0 {
+		return r.URL
+	}
+	u := *r.URL
+	u.Host = r.Host
+	u.Scheme = "http"
+	if r.TLS != nil {
+		u.Scheme = "https"
+	}
+	return &u
+}
+
+// readBody reads up to limit bytes of a body, or all of it if limit is 0, reading one byte more so a longer
+// body can be detected. The body is replaced with one that returns the data read followed by the rest of the body.
+func readBody(body *io.ReadCloser, limit int) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	original := *body
+	var reader io.Reader = original
+	if limit > 0 {
+		reader = io.LimitReader(original, int64(limit)+1)
+	}
+	data, err := ioutil.ReadAll(reader)
+	*body = &readCloser{Reader: io.MultiReader(bytes.NewReader(data), original), Closer: original}
+	return data, err
+}
+
+// shellQuote quotes a string for use as a shell argument
+func shellQuote(arg string) string {
+	return "'" + strings.Replace(arg, "'", `'\''`, -1) + "'"
+}
@@ -0,0 +1,127 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package common
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/paulcarlton/go-utils/pkg/testutils"
+)
+
+func TestRequestDump(t *testing.T) {
+	u, _ := url.Parse("https://host/path?token=abc&page=1")
+	header := http.Header{"Content-Type": []string{"application/json"}, "Authorization": []string{"Bearer secret1"},
+		"Cookie": []string{"session=secret1"}, "Accept": []string{"*/*"}}
+	var tests = []struct {
+		testNum    int
+		body       string
+		opts       DumpOptions
+		expected   []string
+		unexpected []string
+	}{
+		{testNum: 1, body: `{"password":"secret1","user":"admin"}`, opts: DefaultDumpOptions(),
+			expected: []string{"Request: POST https://host/path?page=1&token=%5BREDACTED%5D\n", "Proto: HTTP/1.1\n",
+				"TLS: TLS 1.2, cipher suite TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, server name host",
+				"Headers..\nAccept: */*\nAuthorization: [REDACTED]\nContent-Type: application/json\nCookie: [REDACTED]\n",
+				"Body..\n{\n\t\"password\": \"[REDACTED]\",\n\t\"user\": \"admin\"\n}\n"}},
+		{testNum: 2, body: `{"user":"admin"}`, opts: DumpOptions{MaxBody: 5},
+			expected: []string{"Body..\n{\"use\n[truncated, 5 of 16 bytes shown]\n"}, unexpected: []string{"Headers.."}},
+		{testNum: 3, body: `{"user":"admin"}`, opts: DumpOptions{Headers: true, MaxBody: -1},
+			expected: []string{"Headers.."}, unexpected: []string{"Body.."}},
+		{testNum: 4, body: "\xff\xfe\x00", opts: DefaultDumpOptions(), expected: []string{"Body..\n[3 bytes of binary data]\n"}},
+		{testNum: 5, body: `{"user":"admin","password":"secret1","id":1}`, opts: DumpOptions{MaxBody: 32},
+			expected: []string{"Body..\n{\"user\":\"admin\",\"password\":\"[REDACTED]\"\n[truncated, 32 of 44 bytes shown]\n"}},
+		{testNum: 6, body: strings.Repeat("x", 5000), opts: DefaultDumpOptions(),
+			expected: []string{"\n[truncated, 4096 of 5000 bytes shown]\n"}},
+	}
+
+	for _, test := range tests {
+		r := &http.Request{Method: http.MethodPost, URL: u, Proto: "HTTP/1.1", Header: header,
+			Body: ioutilNopCloser(test.body), ContentLength: int64(len(test.body)), TLS: &tls.ConnectionState{Version: tls.VersionTLS12,
+				CipherSuite: tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, ServerName: "host"}}
+		result := RequestDump(r, test.opts)
+		failed := strings.Contains(result, "secret1") || testutils.FailTests
+		for _, expected := range test.expected {
+			failed = failed || !strings.Contains(result, expected)
+		}
+		for _, unexpected := range test.unexpected {
+			failed = failed || strings.Contains(result, unexpected)
+		}
+		if failed {
+			t.Errorf("\nTest: %d\nExpected:\n%s\nGot.....:\n%s", test.testNum, strings.Join(test.expected, "\n"), result)
+		}
+		if body, _ := ioutil.ReadAll(r.Body); string(body) != test.body || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%s\nGot.....:\n%s", test.testNum, test.body, body)
+		}
+	}
+}
+
+func TestResponseDump(t *testing.T) {
+	u, _ := url.Parse("http://host/path")
+	var tests = []struct {
+		testNum  int
+		resp     *http.Response
+		expected string
+	}{
+		{testNum: 1, resp: nil, expected: "Response: nil\n"},
+		{testNum: 2, resp: &http.Response{Status: "404 Not Found", Proto: "HTTP/1.1",
+			Header:  http.Header{"Set-Cookie": []string{"session=secret1"}, "Content-Type": []string{"text/plain"}},
+			Request: &http.Request{Method: http.MethodGet, URL: u}, Body: ioutilNopCloser("not found")},
+			expected: "Response: 404 Not Found\nProto: HTTP/1.1\nRequest: GET http://host/path\n" +
+				"Headers..\nContent-Type: text/plain\nSet-Cookie: [REDACTED]\nBody..\nnot found\n"},
+		{testNum: 3, resp: &http.Response{Status: "204 No Content", Proto: "HTTP/1.1", Body: http.NoBody},
+			expected: "Response: 204 No Content\nProto: HTTP/1.1\n"},
+	}
+
+	for _, test := range tests {
+		if result := ResponseDump(test.resp, DefaultDumpOptions()); result != test.expected || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%s\nGot.....:\n%s", test.testNum, test.expected, result)
+		}
+	}
+}
+
+func TestToCurl(t *testing.T) {
+	u, _ := url.Parse("https://host/path?token=abc")
+	header := http.Header{"Content-Type": []string{"application/json"}, "Authorization": []string{"Bearer secret1"}}
+	var tests = []struct {
+		testNum  int
+		request  func() *http.Request
+		redact   bool
+		expected string
+	}{
+		{testNum: 1, request: func() *http.Request { return nil }, redact: true, expected: ""},
+		{testNum: 2, request: func() *http.Request {
+			return &http.Request{Method: http.MethodGet, URL: u, Header: http.Header{}}
+		}, redact: true, expected: "curl 'https://host/path?token=%5BREDACTED%5D'"},
+		{testNum: 3, request: func() *http.Request {
+			return &http.Request{Method: http.MethodPost, URL: u, Header: header, Body: ioutilNopCloser(`{"name":"it's"}`)}
+		}, redact: true, expected: `curl -X POST 'https://host/path?token=%5BREDACTED%5D' -H 'Authorization: [REDACTED]' ` +
+			`-H 'Content-Type: application/json' --data-binary '{"name":"it'\''s"}'`},
+		{testNum: 4, request: func() *http.Request {
+			return &http.Request{Method: http.MethodPut, URL: &url.URL{Path: "/path"}, Host: "server:8080",
+				Header: header, Body: ioutilNopCloser("{}")}
+		}, redact: false, expected: `curl -X PUT 'http://server:8080/path' -H 'Authorization: Bearer secret1' ` +
+			`-H 'Content-Type: application/json' --data-binary '{}'`},
+		{testNum: 5, request: func() *http.Request {
+			return &http.Request{Method: http.MethodHead, URL: u, Header: http.Header{}}
+		}, redact: true, expected: "curl -I 'https://host/path?token=%5BREDACTED%5D'"},
+		{testNum: 6, request: func() *http.Request {
+			return &http.Request{Method: http.MethodPost, URL: u, Header: http.Header{},
+				Body: ioutilNopCloser(strings.Repeat("x", 5000))}
+		}, redact: true, expected: "curl -X POST 'https://host/path?token=%5BREDACTED%5D' # body of more than 4096 bytes omitted"},
+	}
+
+	for _, test := range tests {
+		EnableRedaction(test.redact)
+		result := ToCurl(test.request())
+		EnableRedaction(true)
+		if result != test.expected || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%s\nGot.....:\n%s", test.testNum, test.expected, result)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package common
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+type (
+	// Frame describes a function call in the call stack
+	Frame struct {
+		// Function is the name of the function without the package, e.g. (*cerror).Error
+		Function string
+		// Package is the import path of the package the function is declared in
+		Package string
+		// File is the path of the source file
+		File string
+		// Line is the line number in the source file
+		Line int
+	}
+
+	// FrameOptions control which frames CallersFrames returns
+	FrameOptions struct {
+		// SkipRuntime skips the frames of functions in the runtime packages
+		SkipRuntime bool
+		// SkipTesting skips the frames of functions in the testing package
+		SkipTesting bool
+		// SkipVendor skips the frames of functions in vendored packages
+		SkipVendor bool
+		// TrimPrefix is removed from the start of the package and file of each frame, e.g. a module path
+		TrimPrefix string
+		// Boundary stops the frames at the first call of a function, e.g. testing.tRunner, the boundary
+		// can be the package path or package name qualified function name
+		Boundary string
+		// MaxDepth is the maximum number of frames returned, frames skipped are not counted, 0 returns them all
+		MaxDepth uint
+	}
+)
+
+// CallersFrames returns the frames of the call stack, starting with the caller of CallersFrames 'skip' levels back
+// The options are applied in order, so frames skipped are not counted by MaxDepth and the boundary is checked
+// before the prefix is trimmed
+func CallersFrames(skip uint, opts FrameOptions) []Frame {
+	// skip runtime.Callers, callersFrames and CallersFrames
+	return callersFrames(int(skip)+3, opts)
+}
+
+// callersFrames returns the frames of the call stack, skip is passed to runtime.Callers
+func callersFrames(skip int, opts FrameOptions) []Frame {
+	pcs := make([]uintptr, 64)
+	for {
+		n := runtime.Callers(skip, pcs)
+		if n < len(pcs) {
+			pcs = pcs[:n]
+			break
+		}
+		pcs = make([]uintptr, len(pcs)*2)
+	}
+
+	result := []Frame{}
+	frames := runtime.CallersFrames(pcs)
+	for len(pcs) > 0 && (opts.MaxDepth == 0 || len(result) < int(opts.MaxDepth)) {
+		runtimeFrame, more := frames.Next()
+		if runtimeFrame.Line == 0 {
+			break
+		}
+		frame := newFrame(runtimeFrame)
+		if len(opts.Boundary) > 0 && frame.is(opts.Boundary) {
+			break
+		}
+		if !frame.skipped(opts) {
+			frame.trim(opts.TrimPrefix)
+			result = append(result, frame)
+		}
+		if !more {
+			break
+		}
+	}
+	return result
+}
+
+// newFrame creates a Frame from a runtime frame, splitting the package from the function name
+func newFrame(frame runtime.Frame) Frame {
+	name := frame.Function
+	pkgEnd := strings.LastIndex(name, "/") + 1
+	if dot := strings.Index(name[pkgEnd:], "."); dot >= 0 {
+		pkgEnd += dot
+	} else {
+		pkgEnd = 0
+	}
+	return Frame{
+		Function: strings.TrimPrefix(name[pkgEnd:], "."),
+		Package:  name[:pkgEnd],
+		File:     frame.File,
+		Line:     frame.Line,
+	}
+}
+
+// is checks if the frame is a call of a function, the name can be qualified by the package path or name
+func (f Frame) is(name string) bool {
+	return name == f.Package+"."+f.Function || name == f.Name()
+}
+
+// skipped checks if options skip the frame
+func (f Frame) skipped(opts FrameOptions) bool {
+	return (opts.SkipRuntime && (f.Package == "runtime" || strings.HasPrefix(f.Package, "runtime/"))) ||
+		(opts.SkipTesting && f.Package == "testing") ||
+		(opts.SkipVendor && strings.Contains(f.Package, "/vendor/"))
+}
+
+// trim removes a prefix from the package and file of the frame
+func (f *Frame) trim(prefix string) {
+	if len(prefix) == 0 {
+		return
+	}
+	prefix = strings.TrimSuffix(prefix, "/")
+	for _, value := range []*string{&f.Package, &f.File} {
+		if *value == prefix {
+			*value = ""
+		} else if strings.HasPrefix(*value, prefix+"/") {
+			*value = strings.TrimPrefix(*value, prefix+"/")
+		}
+	}
+}
+
+// Name returns the function name qualified by the package name, e.g. core.(*cerror).Error
+func (f Frame) Name() string {
+	if len(f.Package) == 0 {
+		return f.Function
+	}
+	return path.Base(f.Package) + "." + f.Function
+}
+
+// String returns the function name qualified by the package path and the source file path and line number
+// e.g. github.com/paulcarlton/go-utils/pkg/core.MakeError() - /src/pkg/core/error.go(12)
+func (f Frame) String() string {
+	name := f.Function
+	if len(f.Package) > 0 {
+		name = f.Package + "." + f.Function
+	}
+	return fmt.Sprintf("%s() - %s(%d)", name, f.File, f.Line)
+}
+
+// Short returns the package name qualified function name and the source file name and line number
+// e.g. core.MakeError() - error.go(12)
+func (f Frame) Short() string {
+	return fmt.Sprintf("%s() - %s(%d)", f.Name(), filepath.Base(f.File), f.Line)
+}
@@ -0,0 +1,106 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package common
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/paulcarlton/go-utils/pkg/testutils"
+)
+
+const testPackage = "github.com/paulcarlton/go-utils/pkg/internal/common"
+
+// framesOf returns the frames of its caller
+func framesOf(opts FrameOptions) []Frame {
+	return CallersFrames(1, opts)
+}
+
+func TestCallersFrames(t *testing.T) {
+	var tests = []struct {
+		testNum  int
+		frames   []Frame
+		first    Frame
+		expected int
+	}{
+		{testNum: 1, frames: CallersFrames(0, FrameOptions{}), first: Frame{Function: "TestCallersFrames", Package: testPackage}, expected: -1},
+		{testNum: 2, frames: framesOf(FrameOptions{}), first: Frame{Function: "TestCallersFrames", Package: testPackage}, expected: -1},
+		{testNum: 3, frames: CallersFrames(0, FrameOptions{Boundary: "testing.tRunner"}),
+			first: Frame{Function: "TestCallersFrames", Package: testPackage}, expected: 1},
+		{testNum: 4, frames: CallersFrames(0, FrameOptions{Boundary: "testing.tRunner", TrimPrefix: "github.com/paulcarlton/go-utils/"}),
+			first: Frame{Function: "TestCallersFrames", Package: "pkg/internal/common"}, expected: 1},
+		{testNum: 5, frames: CallersFrames(0, FrameOptions{SkipRuntime: true, SkipTesting: true}),
+			first: Frame{Function: "TestCallersFrames", Package: testPackage}, expected: 1},
+		{testNum: 6, frames: CallersFrames(0, FrameOptions{MaxDepth: 1}), first: Frame{Function: "TestCallersFrames", Package: testPackage}, expected: 1},
+		{testNum: 7, frames: CallersFrames(10, FrameOptions{}), expected: 0},
+		{testNum: 8, frames: CallersFrames(0, FrameOptions{SkipTesting: true, MaxDepth: 2}),
+			first: Frame{Function: "TestCallersFrames", Package: testPackage}, expected: 2},
+	}
+
+	for _, test := range tests {
+		ok := test.expected < 0 && len(test.frames) > 1 || len(test.frames) == test.expected
+		if len(test.frames) > 0 {
+			first := test.frames[0]
+			ok = ok && first.Function == test.first.Function && first.Package == test.first.Package &&
+				filepath.Base(first.File) == "frames_test.go" && first.Line > 0
+		}
+		if !ok || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%d frames starting with %+v\nGot.....:\n%+v", test.testNum, test.expected, test.first, test.frames)
+		}
+	}
+}
+
+func TestFrame(t *testing.T) {
+	var tests = []struct {
+		testNum  int
+		frame    runtime.Frame
+		opts     FrameOptions
+		expected Frame
+		short    string
+		long     string
+		skipped  bool
+	}{
+		{
+			testNum:  1,
+			frame:    runtime.Frame{Function: "github.com/paulcarlton/go-utils/pkg/core.(*cerror).Error", File: "/src/pkg/core/error.go", Line: 12},
+			expected: Frame{Function: "(*cerror).Error", Package: "github.com/paulcarlton/go-utils/pkg/core", File: "/src/pkg/core/error.go", Line: 12},
+			short:    "core.(*cerror).Error() - error.go(12)",
+			long:     "github.com/paulcarlton/go-utils/pkg/core.(*cerror).Error() - /src/pkg/core/error.go(12)",
+		},
+		{
+			testNum:  2,
+			frame:    runtime.Frame{Function: "main.main.func1", File: "/src/main.go", Line: 3},
+			expected: Frame{Function: "main.func1", Package: "main", File: "/src/main.go", Line: 3},
+			short:    "main.main.func1() - main.go(3)",
+			long:     "main.main.func1() - /src/main.go(3)",
+		},
+		{
+			testNum:  3,
+			frame:    runtime.Frame{Function: "runtime.goexit", File: "/go/src/runtime/asm_amd64.s", Line: 1},
+			opts:     FrameOptions{SkipRuntime: true},
+			expected: Frame{Function: "goexit", Package: "runtime", File: "/go/src/runtime/asm_amd64.s", Line: 1},
+			short:    "runtime.goexit() - asm_amd64.s(1)",
+			long:     "runtime.goexit() - /go/src/runtime/asm_amd64.s(1)",
+			skipped:  true,
+		},
+		{
+			testNum:  4,
+			frame:    runtime.Frame{Function: "github.com/paulcarlton/go-utils/vendor/gopkg.in/yaml%2ev2.Unmarshal", File: "/src/vendor/gopkg.in/yaml.v2/yaml.go", Line: 5},
+			opts:     FrameOptions{SkipVendor: true},
+			expected: Frame{Function: "Unmarshal", Package: "github.com/paulcarlton/go-utils/vendor/gopkg.in/yaml%2ev2", File: "/src/vendor/gopkg.in/yaml.v2/yaml.go", Line: 5},
+			short:    "yaml%2ev2.Unmarshal() - yaml.go(5)",
+			long:     "github.com/paulcarlton/go-utils/vendor/gopkg.in/yaml%2ev2.Unmarshal() - /src/vendor/gopkg.in/yaml.v2/yaml.go(5)",
+			skipped:  true,
+		},
+	}
+
+	for _, test := range tests {
+		frame := newFrame(test.frame)
+		if frame != test.expected || frame.Short() != test.short || frame.String() != test.long ||
+			frame.skipped(test.opts) != test.skipped || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%+v\n%s\n%s\nGot.....:\n%+v\n%s\n%s", test.testNum, test.expected, test.short, test.long,
+				frame, frame.Short(), frame.String())
+		}
+	}
+}
@@ -8,17 +8,18 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"path/filepath"
-	"runtime"
 	"sort"
 	"strings"
-	"time"
 )
 
 // JSONtext generates a string containing a json representation of an interface
+// Sensitive values are redacted unless redaction is disabled, in which case the value is also printed
 func JSONtext(i interface{}) string {
-	details := fmt.Sprintf("json for %+v...\n", i)
-	if jsonData, err := json.Marshal(i); err != nil {
+	details := fmt.Sprintf("json for %T...\n", i)
+	if !RedactionEnabled() {
+		details = fmt.Sprintf("json for %+v...\n", i)
+	}
+	if jsonData, err := RedactJSON(i); err != nil {
 		details = details + fmt.Sprintf("json marshal error: %s\n", err)
 	} else {
 		if jsonText, err := PrettyJSON(string(jsonData)); err != nil {
@@ -31,8 +32,9 @@ func JSONtext(i interface{}) string {
 }
 
 // RequestDebug generates a string containing details of a request
+// Sensitive query parameters and body values are redacted unless redaction is disabled
 func RequestDebug(r *http.Request) string {
-	debugText := fmt.Sprintf("URL: %+v\n", r.URL)
+	debugText := fmt.Sprintf("URL: %s\n", RedactURL(r.URL))
 	buf, e := ioutil.ReadAll(r.Body)
 	if e != nil {
 		return debugText + fmt.Sprintf("error reading body, %s", e)
@@ -43,7 +45,7 @@ func RequestDebug(r *http.Request) string {
 	if e != nil {
 		debugText = debugText + fmt.Sprintf("error reading body, %s", e)
 	} else {
-		debugText = debugText + fmt.Sprintf("Body..\n%s\n", string(data))
+		debugText = debugText + fmt.Sprintf("Body..\n%s\n", RedactBody(r.Header.Get("Content-Type"), data))
 	}
 	r.Body = rdr2 // OK since rdr2 implements the io.ReadCloser interface
 	return debugText
@@ -58,32 +60,17 @@ func Callers(levels uint, short bool) ([]string, error) {
 	if levels == 0 {
 		return callers, nil
 	}
-	// we get the callers as uintptrs
-	fpcs := make([]uintptr, levels)
 
-	// skip 1 levels to get to the caller of whoever called Callers()
-	n := runtime.Callers(1, fpcs)
-	if n == 0 {
+	// skip runtime.Callers and callersFrames to get to Callers()
+	frames := callersFrames(2, FrameOptions{MaxDepth: levels})
+	if len(frames) == 0 {
 		return nil, fmt.Errorf("caller not availalble")
 	}
-
-	frames := runtime.CallersFrames(fpcs)
-	for {
-		frame, more := frames.Next()
-		if frame.Line == 0 {
-			break
-		}
-		funcName := frame.Function
-		sourceFile := frame.File
-		lineNumber := frame.Line
+	for _, frame := range frames {
 		if short {
-			funcName = filepath.Base(funcName)
-			sourceFile = filepath.Base(sourceFile)
-		}
-		caller := fmt.Sprintf("%s() - %s(%d)", funcName, sourceFile, lineNumber)
-		callers = append(callers, caller)
-		if !more {
-			break
+			callers = append(callers, frame.Short())
+		} else {
+			callers = append(callers, frame.String())
 		}
 	}
 	return callers, nil
@@ -167,22 +154,3 @@ func PrettyJSON(data string) (string, error) {
 	}
 	return prettyJSON.String(), nil
 }
-
-var sleepSecsFunc = sleepSecs
-
-func sleepSecs(seconds uint) {
-	time.Sleep(time.Duration(int64(time.Second) * int64(seconds)))
-}
-
-// ExponentialDelay sleeps for wait time seconds
-// it returns the wait time multiplied by 2 or 1 if the new wait is greater than max
-// The intended usage is to pass a wait time and use the returned value in the next call
-// This causes increasing waits up to max
-func ExponentialDelay(waitTime, max uint) uint {
-	sleepSecsFunc(waitTime)
-	waitTime += waitTime
-	if waitTime > max {
-		waitTime = 1
-	}
-	return waitTime
-}
@@ -0,0 +1,336 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// RedactedText replaces sensitive values in rendered output
+	RedactedText = "[REDACTED]"
+
+	// SensitiveTag is the struct tag that marks a field as sensitive, for example `sensitive:"true"`
+	SensitiveTag = "sensitive"
+
+	// RedactionEnvVar is the environment variable that disables redaction when set to "off"
+	RedactionEnvVar = "GO_UTILS_REDACTION"
+)
+
+var (
+	redactionLock sync.RWMutex
+	// sensitiveKeys match the whole of the keys, field names, headers and query parameters whose values are
+	// redacted, a key may have a prefix such as 'db_', 'X-Auth-' or 'access' but must end with the sensitive word
+	sensitiveKeys = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)^[a-z0-9_.-]*passw(or)?d$`),
+		regexp.MustCompile(`(?i)^[a-z0-9_.-]*token$`),
+		regexp.MustCompile(`(?i)^(proxy-)?authorization$`),
+		regexp.MustCompile(`(?i)^[a-z0-9_.-]*api[-_]?key$`),
+		regexp.MustCompile(`(?i)^[a-z0-9_.-]*private[-_]?key$`),
+	}
+
+	// redactionDisabled is set to 1 when redaction is disabled
+	redactionDisabled int32
+
+	// keyValueText matches 'key: value' and 'key=value' pairs in text, RedactText only treats a 'key: value'
+	// match as a pair if it starts a clause since prose such as 'get token: dial tcp' also matches
+	keyValueText = regexp.MustCompile(`([A-Za-z0-9_.-]+)(\s*[:=]\s*)("[^"]*"|'[^']*'|[^\s,;&?)}\]]+)`)
+	// authSchemeText matches the credentials of a http authorization header value in text
+	authSchemeText = regexp.MustCompile(`(?i)\b(bearer|basic)\s+[A-Za-z0-9._~+/=-]+`)
+	// authScheme matches a http authorization scheme
+	authScheme = regexp.MustCompile(`(?i)^(bearer|basic)$`)
+
+	// secretDataKeys are the keys holding the data of Secret kinds
+	secretDataKeys = []string{"data", "stringData"}
+)
+
+func init() {
+	if strings.EqualFold(os.Getenv(RedactionEnvVar), "off") {
+		EnableRedaction(false)
+	}
+}
+
+// EnableRedaction turns redaction of sensitive values on or off, it is on by default
+// Turning it off is intended for local debugging only
+func EnableRedaction(enabled bool) {
+	value := int32(1)
+	if enabled {
+		value = 0
+	}
+	atomic.StoreInt32(&redactionDisabled, value)
+}
+
+// RedactionEnabled reports whether sensitive values are redacted
+func RedactionEnabled() bool {
+	return atomic.LoadInt32(&redactionDisabled) == 0
+}
+
+// RegisterSensitiveKey adds a regular expression matching keys whose values are redacted, the expression must
+// match the whole key and matching is case insensitive
+func RegisterSensitiveKey(pattern string) error {
+	re, err := regexp.Compile("(?i)^(?:" + pattern + ")$")
+	if err != nil {
+		return fmt.Errorf("invalid sensitive key pattern %s, %s", pattern, err)
+	}
+	redactionLock.Lock()
+	defer redactionLock.Unlock()
+	sensitiveKeys = append(sensitiveKeys, re)
+	return nil
+}
+
+// IsSensitiveKey reports whether the value of a key should be redacted
+func IsSensitiveKey(key string) bool {
+	redactionLock.RLock()
+	defer redactionLock.RUnlock()
+	for _, re := range sensitiveKeys {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactValue returns RedactedText if the key is sensitive and redaction is enabled, otherwise the value
+func RedactValue(key string, value interface{}) interface{} {
+	if RedactionEnabled() && IsSensitiveKey(key) {
+		return RedactedText
+	}
+	return value
+}
+
+// RedactText replaces the values of sensitive 'key: value' and 'key=value' pairs and the credentials of
+// authorization header values in text. A 'key: value' pair must be at the start of the text or follow a ',',
+// ';', '(', '{', '[' or new line, other text containing colons is left unchanged.
+func RedactText(text string) string {
+	if !RedactionEnabled() {
+		return text
+	}
+	text = authSchemeText.ReplaceAllString(text, "$1 "+RedactedText)
+	result := ""
+	last := 0
+	for _, match := range keyValueText.FindAllStringSubmatchIndex(text, -1) {
+		key, separator, value := text[match[2]:match[3]], text[match[4]:match[5]], text[match[6]:match[7]]
+		if !IsSensitiveKey(key) || strings.HasPrefix(text[match[6]:], RedactedText) || authScheme.MatchString(value) ||
+			(!strings.Contains(separator, "=") && !clauseStart(text[:match[0]])) {
+			continue
+		}
+		result += text[last:match[6]] + RedactedText
+		last = match[1]
+	}
+	return result + text[last:]
+}
+
+// clauseStart checks if the text preceding a 'key: value' match ends a clause
+func clauseStart(preceding string) bool {
+	preceding = strings.TrimRight(preceding, " \t")
+	return len(preceding) == 0 || strings.ContainsAny(preceding[len(preceding)-1:], ",;({[\n")
+}
+
+// RedactJSON marshals a value to json with the values of sensitive keys, fields tagged as sensitive and
+// the data of Secret kinds redacted
+func RedactJSON(i interface{}) ([]byte, error) {
+	data, err := json.Marshal(i)
+	if err != nil || !RedactionEnabled() {
+		return data, err
+	}
+	tree, err := decodeJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(redactTree(reflect.ValueOf(i), tree))
+}
+
+// RedactURL returns the text of a url with the password and the values of sensitive query parameters redacted
+func RedactURL(u *url.URL) string {
+	if u == nil || !RedactionEnabled() {
+		return fmt.Sprintf("%+v", u)
+	}
+	result := *u
+	if _, ok := u.User.Password(); ok {
+		result.User = url.UserPassword(u.User.Username(), RedactedText)
+	}
+	if len(u.RawQuery) > 0 {
+		result.RawQuery = redactValues(u.Query()).Encode()
+	}
+	return result.String()
+}
+
+// RedactBody returns the text of a request or response body with sensitive values redacted, json and form
+// bodies are redacted by key, other bodies are redacted as text
+func RedactBody(contentType string, body []byte) string {
+	if !RedactionEnabled() {
+		return string(body)
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		if values, err := url.ParseQuery(string(body)); err == nil {
+			return redactValues(values).Encode()
+		}
+	case len(mediaType) == 0 || strings.HasSuffix(mediaType, "json"):
+		if tree, err := decodeJSON(body); err == nil {
+			if data, err := json.Marshal(redactTree(reflect.Value{}, tree)); err == nil {
+				return string(data)
+			}
+		}
+	}
+	return RedactText(string(body))
+}
+
+// redactValues returns a copy of url values with the values of sensitive keys redacted
+func redactValues(values url.Values) url.Values {
+	result := url.Values{}
+	for key, list := range values {
+		for _, value := range list {
+			result.Add(key, fmt.Sprintf("%v", RedactValue(key, value)))
+		}
+	}
+	return result
+}
+
+// decodeJSON decodes json into generic maps and slices, preserving numbers
+func decodeJSON(data []byte) (interface{}, error) {
+	var tree interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&tree); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+// redactTree redacts a decoded json tree, using the value it was marshaled from, if available, to find
+// fields tagged as sensitive
+func redactTree(v reflect.Value, node interface{}) interface{} {
+	v = indirect(v)
+	switch n := node.(type) {
+	case map[string]interface{}:
+		fields := jsonFields(v)
+		secret := isSecretKind(v, n)
+		for key, value := range n {
+			field, tagged := fields[key]
+			if IsSensitiveKey(key) || (tagged && field.sensitive) || (secret && FindInStringSlice(secretDataKeys, key) >= 0) {
+				n[key] = redactedNode(value)
+				continue
+			}
+			n[key] = redactTree(childValue(v, key, field), value)
+		}
+	case []interface{}:
+		for index := range n {
+			var child reflect.Value
+			if v.IsValid() && (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && index < v.Len() {
+				child = v.Index(index)
+			}
+			n[index] = redactTree(child, n[index])
+		}
+	}
+	return node
+}
+
+// redactedNode replaces a json value, the keys of an object are kept so the redacted output shows which items were present
+func redactedNode(node interface{}) interface{} {
+	if n, ok := node.(map[string]interface{}); ok {
+		for key := range n {
+			n[key] = RedactedText
+		}
+		return n
+	}
+	return RedactedText
+}
+
+// isSecretKind checks if a json object is a kubernetes Secret, either by its kind or by the name of the go type
+func isSecretKind(v reflect.Value, node map[string]interface{}) bool {
+	if kind, ok := node["kind"].(string); ok && kind == "Secret" {
+		return true
+	}
+	return v.IsValid() && v.Kind() == reflect.Struct && v.Type().Name() == "Secret"
+}
+
+// indirect follows pointers and interfaces to the value they refer to
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// childValue returns the value a json object member was marshaled from
+func childValue(v reflect.Value, key string, field jsonField) reflect.Value {
+	if !v.IsValid() {
+		return reflect.Value{}
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		if field.index == nil {
+			return reflect.Value{}
+		}
+		child, err := v.FieldByIndexErr(field.index)
+		if err != nil {
+			return reflect.Value{}
+		}
+		return child
+	case reflect.Map:
+		if v.Type().Key().Kind() == reflect.String {
+			return v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key()))
+		}
+	}
+	return reflect.Value{}
+}
+
+// jsonField describes the struct field a json object member is marshaled from
+type jsonField struct {
+	index     []int
+	sensitive bool
+}
+
+// jsonFields returns the fields of a struct by json name, including the fields of embedded structs
+func jsonFields(v reflect.Value) map[string]jsonField {
+	fields := map[string]jsonField{}
+	if v.IsValid() && v.Kind() == reflect.Struct {
+		addJSONFields(v.Type(), nil, fields)
+	}
+	return fields
+}
+
+// addJSONFields adds the fields of a struct type to fields, prefixing their index with the index of the embedding field
+func addJSONFields(t reflect.Type, prefix []int, fields map[string]jsonField) {
+	for index := 0; index < t.NumField(); index++ {
+		field := t.Field(index)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		path := append(append([]int{}, prefix...), index)
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && len(name) == 0 && fieldType.Kind() == reflect.Struct {
+			addJSONFields(fieldType, path, fields)
+			continue
+		}
+		if len(field.PkgPath) > 0 {
+			continue
+		}
+		if len(name) == 0 {
+			name = field.Name
+		}
+		if _, ok := fields[name]; !ok {
+			fields[name] = jsonField{index: path, sensitive: field.Tag.Get(SensitiveTag) == "true"}
+		}
+	}
+}
@@ -0,0 +1,17 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package k8s
+
+import (
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/paulcarlton/go-utils/pkg/core"
+)
+
+func init() {
+	// Kubernetes api errors carry a status reason which is used to set the code
+	core.RegisterClassifier(core.PredicateClassifier("k8s.not-found", core.ErrorNotFound, apierrors.IsNotFound))
+	core.RegisterClassifier(core.PredicateClassifier("k8s.already-exists", core.ErrorDuplicateEntry, apierrors.IsAlreadyExists))
+	core.RegisterClassifier(core.PredicateClassifier("k8s.conflict", core.ErrorDuplicateEntry, apierrors.IsConflict))
+	core.RegisterClassifier(core.PredicateClassifier("k8s.forbidden", core.ErrorUnauthorized, apierrors.IsForbidden))
+}
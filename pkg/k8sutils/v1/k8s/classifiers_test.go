@@ -0,0 +1,49 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package k8s
+
+import (
+	"fmt"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/paulcarlton/go-utils/pkg/core"
+)
+
+func TestClassifiers(t *testing.T) {
+	resource := schema.GroupResource{Resource: "secrets"}
+	cases := []struct {
+		nested   error
+		expected int
+	}{
+		{
+			nested:   apierrors.NewNotFound(resource, "test"),
+			expected: core.ErrorNotFound,
+		},
+		{
+			nested:   apierrors.NewAlreadyExists(resource, "test"),
+			expected: core.ErrorDuplicateEntry,
+		},
+		{
+			nested:   apierrors.NewConflict(resource, "test", fmt.Errorf("modified")),
+			expected: core.ErrorDuplicateEntry,
+		},
+		{
+			nested:   apierrors.NewForbidden(resource, "test", fmt.Errorf("denied")),
+			expected: core.ErrorUnauthorized,
+		},
+		{
+			nested:   apierrors.NewBadRequest("bad"),
+			expected: core.ErrorUnknown,
+		},
+	}
+
+	for _, c := range cases {
+		err := core.RaiseError("test", core.ErrorUnknown, "failed trying to find secret", c.nested)
+		if code := err.(core.Error).Code(); code != c.expected {
+			t.Errorf("Expected code %d for %s, got %d", c.expected, c.nested, code)
+		}
+	}
+}
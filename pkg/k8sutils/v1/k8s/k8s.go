@@ -3,10 +3,9 @@
 package k8s
 
 import (
-	"strings"
-
 	"github.com/ugorji/go/codec"
 	"k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
@@ -15,6 +14,9 @@ import (
 	k8sutilsv1 "github.com/paulcarlton/go-utils/pkg/k8sutils/v1"
 )
 
+// id is the id of the errors that are not about a kubernetes object
+const id = "k8s"
+
 // K8s is a structure that hold a kubernetes client and implements the K8sUtils interface
 type K8s struct {
 	k8sutilsv1.K8sUtilsImpl
@@ -34,11 +36,11 @@ func (k8s *K8s) GetClientset() kubernetes.Interface {
 func (k8s *K8s) SetClientset(configFileData []byte) error {
 	config, err := clientcmd.RESTConfigFromKubeConfig(configFileData)
 	if err != nil {
-		return core.RaiseError("", core.ErrorUnknown, "failed trying to build config", err)
+		return core.RaiseError(id, core.ErrorUnknown, "failed trying to build config", err)
 	}
 	k8s.Client, err = kubernetes.NewForConfig(config)
 	if err != nil {
-		return core.RaiseError("", core.ErrorUnknown, "failed trying to get clientset", err)
+		return core.RaiseError(id, core.ErrorUnknown, "failed trying to get clientset", err)
 	}
 	return nil
 }
@@ -48,11 +50,11 @@ func (k8s *K8s) FindK8sSecret(secret *v1.Secret) (bool, error) {
 
 	_, err := k8sGetSecret(k8s, secret)
 
-	// If the error is "not found" we just return false
-	if err != nil && strings.Contains(err.Error(), "not found") {
+	// If the secret is not found we just return false
+	if core.IsNotFound(err) {
 		return false, nil
 	} else if err != nil {
-		return false, core.RaiseError(secret.Name, core.ErrorUnknown, "failed trying to find secret", err)
+		return false, core.WithFields(core.RaiseError(secret.Name, core.ErrorUnknown, "failed trying to find secret", err), objectFields(secret))
 	}
 
 	return true, nil
@@ -62,7 +64,10 @@ func (k8s *K8s) FindK8sSecret(secret *v1.Secret) (bool, error) {
 func (k8s *K8s) CreateK8sSecret(secret *v1.Secret) error {
 
 	if _, err := k8s.Client.CoreV1().Secrets(secret.Namespace).Create(secret); err != nil {
-		return core.RaiseError(secret.Name, core.ErrorUnknown, "failed trying to create secret", err)
+		if apierrors.IsAlreadyExists(err) {
+			return core.WithFields(core.RaiseReasonError(secret.Name, ReasonSecretConflict, "", err), objectFields(secret))
+		}
+		return core.WithFields(core.RaiseError(secret.Name, core.ErrorUnknown, "failed trying to create secret", err), objectFields(secret))
 	}
 	return nil
 }
@@ -71,7 +76,7 @@ func (k8s *K8s) CreateK8sSecret(secret *v1.Secret) error {
 func (k8s *K8s) UpdateK8sSecret(secret *v1.Secret) error {
 
 	if _, err := k8s.Client.CoreV1().Secrets(secret.Namespace).Update(secret); err != nil {
-		return core.RaiseError(secret.Name, core.ErrorUnknown, "failed trying to update secret", err)
+		return core.WithFields(core.RaiseError(secret.Name, core.ErrorUnknown, "failed trying to update secret", err), objectFields(secret))
 	}
 	return nil
 }
@@ -80,7 +85,7 @@ func (k8s *K8s) UpdateK8sSecret(secret *v1.Secret) error {
 func (k8s *K8s) DeleteK8sSecret(secret *v1.Secret) error {
 
 	if err := k8s.Client.CoreV1().Secrets(secret.Namespace).Delete(secret.Name, &metav1.DeleteOptions{}); err != nil {
-		return core.RaiseError(secret.Name, core.ErrorUnknown, "failed trying to delete secret", err)
+		return core.WithFields(core.RaiseError(secret.Name, core.ErrorUnknown, "failed trying to delete secret", err), objectFields(secret))
 	}
 	return nil
 }
@@ -89,8 +94,10 @@ func (k8s *K8s) DeleteK8sSecret(secret *v1.Secret) error {
 func (k8s *K8s) GetK8sSecret(secret *v1.Secret) (*v1.Secret, error) {
 
 	foundSecret, err := k8sGetSecret(k8s, secret)
-	if err != nil {
-		return foundSecret, core.RaiseError(secret.Name, core.ErrorUnknown, "failed trying to find secret", err)
+	if err != nil && apierrors.IsNotFound(err) {
+		return foundSecret, core.WithFields(core.RaiseReasonError(secret.Name, ReasonSecretNotFound, "", err), objectFields(secret))
+	} else if err != nil {
+		return foundSecret, core.WithFields(core.RaiseError(secret.Name, core.ErrorUnknown, "failed trying to find secret", err), objectFields(secret))
 	}
 	return foundSecret, nil
 }
@@ -99,11 +106,11 @@ func (k8s *K8s) GetK8sSecret(secret *v1.Secret) (*v1.Secret, error) {
 func (k8s *K8s) FindK8sConfigMap(configMap *v1.ConfigMap) (bool, error) {
 
 	if _, err := k8s.Client.CoreV1().ConfigMaps(configMap.Namespace).Get(configMap.Name, metav1.GetOptions{});
-	// If the error is "not found" we just return false
-	err != nil && strings.Contains(err.Error(), "not found") {
+	// If the configmap is not found we just return false
+	core.IsNotFound(err) {
 		return false, nil
 	} else if err != nil {
-		return false, core.RaiseError(configMap.Name, core.ErrorUnknown, "failed trying to find configmap", err)
+		return false, core.WithFields(core.RaiseError(configMap.Name, core.ErrorUnknown, "failed trying to find configmap", err), objectFields(configMap))
 	}
 
 	return true, nil
@@ -113,7 +120,10 @@ func (k8s *K8s) FindK8sConfigMap(configMap *v1.ConfigMap) (bool, error) {
 func (k8s *K8s) CreateK8sConfigMap(configMap *v1.ConfigMap) error {
 
 	if _, err := k8s.Client.CoreV1().ConfigMaps(configMap.Namespace).Create(configMap); err != nil {
-		return core.RaiseError(configMap.Name, core.ErrorUnknown, "failed trying to create configmap", err)
+		if apierrors.IsAlreadyExists(err) {
+			return core.WithFields(core.RaiseReasonError(configMap.Name, ReasonConfigMapConflict, "", err), objectFields(configMap))
+		}
+		return core.WithFields(core.RaiseError(configMap.Name, core.ErrorUnknown, "failed trying to create configmap", err), objectFields(configMap))
 	}
 
 	return nil
@@ -123,7 +133,7 @@ func (k8s *K8s) CreateK8sConfigMap(configMap *v1.ConfigMap) error {
 func (k8s *K8s) UpdateK8sConfigMap(configMap *v1.ConfigMap) error {
 
 	if _, err := k8s.Client.CoreV1().ConfigMaps(configMap.Namespace).Update(configMap); err != nil {
-		return core.RaiseError(configMap.Name, core.ErrorUnknown, "failed trying to update configmap", err)
+		return core.WithFields(core.RaiseError(configMap.Name, core.ErrorUnknown, "failed trying to update configmap", err), objectFields(configMap))
 	}
 
 	return nil
@@ -133,12 +143,17 @@ func (k8s *K8s) UpdateK8sConfigMap(configMap *v1.ConfigMap) error {
 func (k8s *K8s) DeleteK8sConfigMap(configMap *v1.ConfigMap) error {
 
 	if err := k8s.Client.CoreV1().ConfigMaps(configMap.Namespace).Delete(configMap.Name, &metav1.DeleteOptions{}); err != nil {
-		return core.RaiseError(configMap.Name, core.ErrorUnknown, "failed trying to delete configmap", err)
+		return core.WithFields(core.RaiseError(configMap.Name, core.ErrorUnknown, "failed trying to delete configmap", err), objectFields(configMap))
 	}
 
 	return nil
 }
 
+// objectFields returns the core.Error context fields for a kubernetes object
+func objectFields(object metav1.Object) core.Fields {
+	return core.Fields{"name": object.GetName(), "namespace": object.GetNamespace()}
+}
+
 // getHandle returns a new json handler
 func getHandle() *codec.JsonHandle {
 	h := new(codec.JsonHandle)
@@ -161,7 +176,7 @@ func decodeK8s(k8sData []byte) (interface{}, error) {
 	dec := codec.NewDecoderBytes(k8sData, getHandle())
 	err := dec.Decode(&typeMeta)
 	if err != nil {
-		return nil, core.RaiseError("", core.ErrorUnknown, "failed to decode k8s data", err)
+		return nil, core.RaiseError(id, core.ErrorUnknown, "failed to decode k8s data", err)
 	}
 	dec.ResetBytes(k8sData)
 
@@ -170,13 +185,13 @@ func decodeK8s(k8sData []byte) (interface{}, error) {
 	case "ConfigMap":
 		var configMap v1.ConfigMap
 		if err = dec.Decode(&configMap); err != nil {
-			coreErr = core.RaiseError("", core.ErrorUnknown, "failed to decode k8s configmap", err)
+			coreErr = core.RaiseError(id, core.ErrorUnknown, "failed to decode k8s configmap", err)
 		}
 		data = &configMap
 	case "Secret":
 		var secret v1.Secret
 		if err = dec.Decode(&secret); err != nil {
-			coreErr = core.RaiseError("", core.ErrorUnknown, "failed to decode k8s secret", err)
+			coreErr = core.RaiseError(id, core.ErrorUnknown, "failed to decode k8s secret", err)
 		}
 		data = &secret
 	default:
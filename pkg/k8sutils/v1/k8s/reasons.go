@@ -0,0 +1,37 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package k8s
+
+import (
+	"github.com/paulcarlton/go-utils/pkg/core"
+)
+
+const (
+	// ReasonSecretConflict The secret already exists
+	ReasonSecretConflict string = "k8s.secret.conflict"
+	// ReasonSecretNotFound The secret does not exist
+	ReasonSecretNotFound string = "k8s.secret.not-found"
+	// ReasonConfigMapConflict The configmap already exists
+	ReasonConfigMapConflict string = "k8s.configmap.conflict"
+)
+
+func init() {
+	core.MustRegisterReason(core.Reason{
+		Name:               ReasonSecretConflict,
+		Code:               core.ErrorDuplicateEntry,
+		Message:            "secret already exists",
+		RecommendedActions: []string{"update the existing secret or delete it before creating it"},
+	})
+	core.MustRegisterReason(core.Reason{
+		Name:               ReasonSecretNotFound,
+		Code:               core.ErrorNotFound,
+		Message:            "secret not found",
+		RecommendedActions: []string{"check the secret name and namespace"},
+	})
+	core.MustRegisterReason(core.Reason{
+		Name:               ReasonConfigMapConflict,
+		Code:               core.ErrorDuplicateEntry,
+		Message:            "configmap already exists",
+		RecommendedActions: []string{"update the existing configmap or delete it before creating it"},
+	})
+}
@@ -0,0 +1,23 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package location
+
+import (
+	"sync"
+
+	"github.com/paulcarlton/go-utils/pkg/core"
+)
+
+var registerOnce sync.Once
+
+// RegisterClassifiers registers classifiers for the conditions location handler backends report in their error
+// text. The classifiers match on substrings of the error text so they apply to errors from any library, they are
+// not registered on import, call this function once in a program that wants them applied.
+func RegisterClassifiers() {
+	registerOnce.Do(func() {
+		core.RegisterClassifier(core.SubstringClassifier("location.not-found", core.ErrorNotFound, "not found"))
+		core.RegisterClassifier(core.SubstringClassifier("location.conflict", core.ErrorDuplicateEntry, "conflict"))
+		core.RegisterClassifier(core.SubstringClassifier("location.forbidden", core.ErrorUnauthorized, "forbidden"))
+		core.RegisterClassifier(core.SubstringClassifier("location.uri-parse", core.ErrorInvalidInput, ErrorStringURIParseFail))
+	})
+}
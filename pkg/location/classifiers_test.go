@@ -0,0 +1,29 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package location
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/paulcarlton/go-utils/pkg/core"
+	"github.com/paulcarlton/go-utils/pkg/testutils"
+)
+
+func TestRegisterClassifiers(t *testing.T) {
+	plainErr := errors.New("object not found")
+	if core.IsNotFound(plainErr) || testutils.FailTests {
+		t.Errorf("\nTest: 1\nExpected:\nnot classified before RegisterClassifiers\nGot.....:\n%s", core.ErrorText(plainErr))
+	}
+	RegisterClassifiers()
+	RegisterClassifiers()
+	count := 0
+	for _, classifier := range core.Classifiers() {
+		if classifier.Name == "location.not-found" {
+			count++
+		}
+	}
+	if !core.IsNotFound(plainErr) || count != 1 || testutils.FailTests {
+		t.Errorf("\nTest: 2\nExpected:\nclassified once\nGot.....:\n%d classifiers, %t", count, core.IsNotFound(plainErr))
+	}
+}
@@ -70,15 +70,21 @@ func GetHandler() (location.Handler, error) {
 func (memory *memory) VerifyScheme(uri string) error {
 	uriParts, err := url.Parse(uri)
 	if err != nil {
-		return core.RaiseError("", core.ErrorInvalidInput, fmt.Sprintf("%s %s", location.ErrorStringURIParseFail, uri), err)
+		return core.WithFields(core.RaiseReasonError(memory.ID(), location.ReasonURIParseFail, "", err), uriFields(uri))
 	}
 
 	if uriParts.Scheme != memory.Scheme() {
-		return core.MakeError(memory.ID(), core.ErrorInvalidInput, fmt.Sprintf("%s %s:", location.ErrorStringURISchemeMismatch, memory.Scheme()))
+		return core.WithFields(core.MakeReasonError(memory.ID(), location.ReasonURISchemeMismatch,
+			fmt.Sprintf("%s %s:", location.ErrorStringURISchemeMismatch, memory.Scheme())), uriFields(uri))
 	}
 	return nil
 }
 
+// uriFields returns the core.Error context fields for a uri
+func uriFields(uri string) core.Fields {
+	return core.Fields{"uri": uri}
+}
+
 // getSession resuses an existing session or gets a new one
 func (memory *memory) getSession(uri string) (data, error) {
 	if err := memory.VerifyScheme(uri); err != nil {
@@ -99,7 +105,7 @@ func (memory *memory) getSession(uri string) (data, error) {
 // will reuse this session if it hasn't expired.
 func (memory *memory) Connect(uri string) error {
 	if _, err := memory.getSession(uri); err != nil {
-		return core.RaiseError(memory.ID(), core.ErrorUnknown, "failed to connect", err)
+		return core.WithFields(core.RaiseError(memory.ID(), core.ErrorUnknown, "failed to connect", err), uriFields(uri))
 	}
 
 	return nil
@@ -133,12 +139,12 @@ func list(data *data, path string) *PathInfo {
 func (memory *memory) ListData(uri string) ([]string, error) {
 	uriParts, err := url.Parse(uri)
 	if err != nil {
-		return nil, core.RaiseError(memory.ID(), core.ErrorInvalidInput, ErrorConnectFail, err)
+		return nil, core.WithFields(core.RaiseError(memory.ID(), core.ErrorInvalidInput, ErrorConnectFail, err), uriFields(uri))
 	}
 
 	session, err := memory.getSession(uri)
 	if err != nil {
-		return nil, core.RaiseError(memory.ID(), core.ErrorUnknown, ErrorConnectFail, err)
+		return nil, core.WithFields(core.RaiseError(memory.ID(), core.ErrorUnknown, ErrorConnectFail, err), uriFields(uri))
 	}
 
 	return list(&session, uriParts.Path).ItemList, nil
@@ -148,12 +154,12 @@ func (memory *memory) ListData(uri string) ([]string, error) {
 func (memory *memory) DeleteData(uri string) error {
 	uriParts, err := url.Parse(uri)
 	if err != nil {
-		return core.RaiseError(memory.ID(), core.ErrorInvalidInput, ErrorConnectFail, err)
+		return core.WithFields(core.RaiseError(memory.ID(), core.ErrorInvalidInput, ErrorConnectFail, err), uriFields(uri))
 	}
 
 	session, err := memory.getSession(uri)
 	if err != nil {
-		return core.RaiseError(memory.ID(), core.ErrorUnknown, ErrorConnectFail, err)
+		return core.WithFields(core.RaiseError(memory.ID(), core.ErrorUnknown, ErrorConnectFail, err), uriFields(uri))
 	}
 
 	if _, ok := session[uriParts.Path]; !ok {
@@ -168,31 +174,32 @@ func (memory *memory) DeleteData(uri string) error {
 func (memory *memory) GetData(uri string) (interface{}, error) {
 	uriParts, err := url.Parse(uri)
 	if err != nil {
-		return nil, core.RaiseError(memory.ID(), core.ErrorInvalidInput, ErrorConnectFail, err)
+		return nil, core.WithFields(core.RaiseError(memory.ID(), core.ErrorInvalidInput, ErrorConnectFail, err), uriFields(uri))
 	}
 
 	session, err := memory.getSession(uri)
 	if err != nil {
-		return nil, core.RaiseError(memory.ID(), core.ErrorUnknown, ErrorConnectFail, err)
+		return nil, core.WithFields(core.RaiseError(memory.ID(), core.ErrorUnknown, ErrorConnectFail, err), uriFields(uri))
 	}
 
 	if value, ok := session[uriParts.Path]; ok {
 		return value, nil
 	}
 
-	return nil, core.MakeError(memory.ID(), core.ErrorNotFound, fmt.Sprintf("no data at: %s", uriParts.Path))
+	return nil, core.WithFields(core.MakeError(memory.ID(), core.ErrorNotFound, fmt.Sprintf("no data at: %s", uriParts.Path)),
+		uriFields(uri))
 }
 
 // PutData sets data value for a uri into the memory backend
 func (memory *memory) PutData(uri string, data interface{}) error {
 	uriParts, err := url.Parse(uri)
 	if err != nil {
-		return core.RaiseError(memory.ID(), core.ErrorUnknown, location.ErrorStringURIParseFail, err)
+		return core.WithFields(core.RaiseError(memory.ID(), core.ErrorUnknown, location.ErrorStringURIParseFail, err), uriFields(uri))
 	}
 
 	session, err := memory.getSession(uri)
 	if err != nil {
-		return core.RaiseError(memory.ID(), core.ErrorUnknown, ErrorConnectFail, err)
+		return core.WithFields(core.RaiseError(memory.ID(), core.ErrorUnknown, ErrorConnectFail, err), uriFields(uri))
 	}
 
 	session[uriParts.Path] = data
@@ -29,6 +29,11 @@ func TestMemoryLocationHandlerVerifyScheme(t *testing.T) {
 	if !strings.Contains(err.Error(), location.ErrorStringURISchemeMismatch) {
 		t.Errorf("Returned Error: %s", err)
 	}
+
+	// The uri should be reported as a field
+	if coreErr, ok := err.(core.Error); !ok || coreErr.Fields()["uri"] != "test.local" {
+		t.Errorf("Returned Error: %s", core.ErrorText(err))
+	}
 }
 
 func TestMemoryLocationHandler_getSession(t *testing.T) {
@@ -144,9 +149,10 @@ func TestGetData(t *testing.T) {
 			description: "data not present",
 			input:       fmt.Sprintf("memory://%s", emptyPath),
 			expected: &expected{result: nil,
-				err: core.MakeErrorAt(HandlerID, core.ErrorNotFound,
+				err: core.WithFields(core.MakeErrorAt(HandlerID, core.ErrorNotFound,
 					fmt.Sprintf("no data at: %s", emptyPath),
-					"memory.(*memory).GetData() - handler.go(183)")},
+					"memory.(*memory).GetData() - handler.go(189)"),
+					core.Fields{"uri": fmt.Sprintf("memory://%s", emptyPath)})},
 			setupFunc: notFoundSetup},
 	}
 
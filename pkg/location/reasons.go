@@ -0,0 +1,36 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package location
+
+import (
+	"github.com/paulcarlton/go-utils/pkg/core"
+)
+
+const (
+	// ReasonURISchemeMismatch The scheme provided in the URI doesn't match the scheme implemented by the handler
+	ReasonURISchemeMismatch string = "location.uri.scheme-mismatch"
+	// ReasonURIParseFail The provided URI is malformed and couldn't be parsed
+	ReasonURIParseFail string = "location.uri.parse-failed"
+	// ReasonDataNotFound There is no data at the location
+	ReasonDataNotFound string = "location.data.not-found"
+)
+
+func init() {
+	core.MustRegisterReason(core.Reason{
+		Name:               ReasonURISchemeMismatch,
+		Code:               core.ErrorInvalidInput,
+		Message:            ErrorStringURISchemeMismatch,
+		RecommendedActions: []string{"use a uri with the scheme implemented by the handler"},
+	})
+	core.MustRegisterReason(core.Reason{
+		Name:               ReasonURIParseFail,
+		Code:               core.ErrorInvalidInput,
+		Message:            ErrorStringURIParseFail,
+		RecommendedActions: []string{"check the uri is of the form scheme://userinfo@host:port/path?query#fragment"},
+	})
+	core.MustRegisterReason(core.Reason{
+		Name:    ReasonDataNotFound,
+		Code:    core.ErrorNotFound,
+		Message: "no data at location",
+	})
+}
@@ -0,0 +1,182 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/paulcarlton/go-utils/pkg/core"
+	"github.com/paulcarlton/go-utils/pkg/internal/common"
+)
+
+// pair is a key and value of a record, pairs are written in order
+type pair struct {
+	key   string
+	value interface{}
+}
+
+// encode returns a record in a format, without a trailing newline
+func encode(record *Record, format Format) []byte {
+	pairs := record.pairs()
+	if format == JSONFormat {
+		return encodeJSON(pairs)
+	}
+	return encodeLogfmt(pairs)
+}
+
+// pairs returns the keys and values of a record, the fields of the record and of its error follow the
+// standard keys in key order. Fields do not replace standard keys.
+func (record *Record) pairs() []pair {
+	pairs := []pair{
+		{key: "time", value: record.Time.UTC().Format(time.RFC3339Nano)},
+		{key: "level", value: record.Level.String()},
+		{key: "msg", value: common.RedactText(record.Message)},
+		{key: "caller", value: record.Caller},
+	}
+	fields := core.Fields{}
+	if record.Error != nil {
+		pairs = append(pairs, errorPairs(record.Error)...)
+		if coreErr, ok := record.Error.(core.Error); ok {
+			for key, value := range coreErr.Fields() {
+				fields[key] = value
+			}
+		}
+	}
+	for key, value := range record.Fields {
+		fields[key] = value
+	}
+
+	used := map[string]bool{}
+	for _, p := range pairs {
+		used[p.key] = true
+	}
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		if !used[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		pairs = append(pairs, pair{key: key, value: common.RedactValue(key, fields[key])})
+	}
+	return pairs
+}
+
+// errorPairs returns the keys and values reporting an error
+func errorPairs(err error) []pair {
+	entry := errorEntry(err)
+	pairs := []pair{{key: "error", value: entry["message"]}}
+	for _, key := range []string{"code", "codeText", "reason", "id", "where"} {
+		if value, ok := entry[key]; ok {
+			pairs = append(pairs, pair{key: key, value: value})
+		}
+	}
+	if list, ok := err.(*core.ErrorList); ok {
+		errs := []map[string]interface{}{}
+		for _, member := range list.Errors() {
+			errs = append(errs, errorEntry(member))
+		}
+		pairs = append(pairs, pair{key: "errors", value: errs})
+	}
+	if nested := nestedChain(err); len(nested) > 0 {
+		pairs = append(pairs, pair{key: "nested", value: nested})
+	}
+	return pairs
+}
+
+// nestedChain returns an entry for each error in the nested chain of an error
+func nestedChain(err error) []map[string]interface{} {
+	chain := []map[string]interface{}{}
+	for nested := nestedError(err); nested != nil; nested = nestedError(nested) {
+		chain = append(chain, errorEntry(nested))
+	}
+	return chain
+}
+
+// nestedError returns the error nested in a core.Error or wrapped by another error
+func nestedError(err error) error {
+	if coreErr, ok := err.(core.Error); ok {
+		return coreErr.Nested()
+	}
+	if wrapper, ok := err.(interface{ Unwrap() error }); ok {
+		return wrapper.Unwrap()
+	}
+	return nil
+}
+
+// errorEntry returns the keys and values reporting a single error, without its nested errors
+func errorEntry(err error) map[string]interface{} {
+	coreErr, ok := err.(core.Error)
+	if !ok {
+		return map[string]interface{}{"message": common.RedactText(err.Error())}
+	}
+	entry := map[string]interface{}{
+		"message":  common.RedactText(coreErr.Message()),
+		"code":     coreErr.Code(),
+		"codeText": core.CodeText(coreErr.Code()),
+	}
+	for key, value := range map[string]string{"reason": coreErr.Reason(), "id": coreErr.ID(), "where": coreErr.Where()} {
+		if len(value) > 0 {
+			entry[key] = value
+		}
+	}
+	return entry
+}
+
+// encodeJSON writes pairs as a json object with the keys in order
+func encodeJSON(pairs []pair) []byte {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for index, p := range pairs {
+		if index > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(p.key)
+		value, err := json.Marshal(p.value)
+		if err != nil {
+			value, _ = json.Marshal(fmt.Sprintf("%v", p.value))
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes()
+}
+
+// encodeLogfmt writes pairs as space separated key=value pairs, arrays and objects are written as json
+func encodeLogfmt(pairs []pair) []byte {
+	text := make([]string, 0, len(pairs))
+	for _, p := range pairs {
+		text = append(text, fmt.Sprintf("%s=%s", p.key, logfmtValue(p.value)))
+	}
+	return []byte(strings.Join(text, " "))
+}
+
+// logfmtValue returns the text of a value, quoted if it is empty or contains spaces, quotes or equals signs
+func logfmtValue(value interface{}) string {
+	var text string
+	switch v := value.(type) {
+	case string:
+		text = v
+	case []map[string]interface{}, map[string]interface{}, []interface{}:
+		data, err := json.Marshal(v)
+		if err != nil {
+			data = []byte(fmt.Sprintf("%v", v))
+		}
+		text = string(data)
+	default:
+		text = fmt.Sprintf("%v", v)
+	}
+	if len(text) == 0 || strings.ContainsAny(text, " =\"\t\n\\") {
+		return strconv.Quote(text)
+	}
+	return text
+}
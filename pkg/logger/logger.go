@@ -0,0 +1,160 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+// Package logger provides a leveled logger that writes one logfmt or json record per line, reporting
+// core.Error values as fields so the records can be parsed by log shippers and aggregators.
+package logger
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/paulcarlton/go-utils/pkg/core"
+	"github.com/paulcarlton/go-utils/pkg/internal/common"
+)
+
+// Level is the severity of a log record
+type Level int
+
+const (
+	// DebugLevel is used for detailed diagnostic records
+	DebugLevel Level = iota
+	// InfoLevel is used for records of normal operation
+	InfoLevel
+	// WarnLevel is used for records of failures caused by the client, such as 4xx errors
+	WarnLevel
+	// ErrorLevel is used for records of failures of the service, such as 5xx errors
+	ErrorLevel
+)
+
+// Format is the encoding of log records
+type Format int
+
+const (
+	// LogfmtFormat writes records as key=value pairs
+	LogfmtFormat Format = iota
+	// JSONFormat writes records as json objects
+	JSONFormat
+)
+
+type (
+	// Record is a log record
+	Record struct {
+		Time    time.Time
+		Level   Level
+		Message string
+		Caller  string
+		Error   error
+		Fields  core.Fields
+	}
+
+	// Hook is called with each record that is logged, before it is written, so services can forward
+	// records elsewhere. Hooks must not retain the record after returning or log to the same Logger.
+	Hook func(record *Record)
+
+	// Logger writes leveled log records to an io.Writer
+	Logger struct {
+		lock   sync.Mutex
+		out    io.Writer
+		format Format
+		level  Level
+		hooks  []Hook
+		now    func() time.Time
+	}
+)
+
+var levelText = map[Level]string{
+	DebugLevel: "debug",
+	InfoLevel:  "info",
+	WarnLevel:  "warn",
+	ErrorLevel: "error",
+}
+
+// String returns the name of a level
+func (l Level) String() string {
+	if text, ok := levelText[l]; ok {
+		return text
+	}
+	return "unknown"
+}
+
+// LevelFor returns the level to log an error at, core.Error codes in the 4xx range are logged as warnings
+// and all other errors, including 5xx codes and ErrorUnknown, are logged as errors
+func LevelFor(err error) Level {
+	if coreErr, ok := err.(core.Error); ok {
+		code := coreErr.Code()
+		if code >= http.StatusBadRequest && code < http.StatusInternalServerError && code != core.ErrorUnknown {
+			return WarnLevel
+		}
+	}
+	return ErrorLevel
+}
+
+// New creates a Logger that writes records at InfoLevel and above to out
+func New(out io.Writer, format Format) *Logger {
+	return &Logger{out: out, format: format, level: InfoLevel, now: time.Now}
+}
+
+// SetLevel sets the minimum level of the records that are logged
+func (l *Logger) SetLevel(level Level) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.level = level
+}
+
+// AddHook adds a hook that is called with each record that is logged
+func (l *Logger) AddHook(hook Hook) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.hooks = append(l.hooks, hook)
+}
+
+// Debug logs a message at DebugLevel
+func (l *Logger) Debug(msg string, fields core.Fields) {
+	l.log(DebugLevel, msg, nil, fields)
+}
+
+// Info logs a message at InfoLevel
+func (l *Logger) Info(msg string, fields core.Fields) {
+	l.log(InfoLevel, msg, nil, fields)
+}
+
+// Warn logs a message at WarnLevel
+func (l *Logger) Warn(msg string, fields core.Fields) {
+	l.log(WarnLevel, msg, nil, fields)
+}
+
+// Error logs a message at ErrorLevel
+func (l *Logger) Error(msg string, fields core.Fields) {
+	l.log(ErrorLevel, msg, nil, fields)
+}
+
+// LogError logs an error at the level returned by LevelFor. The code, id, reason, where and fields of a
+// core.Error are added to the record and its nested errors are reported as an array.
+func (l *Logger) LogError(err error, msg string, fields core.Fields) {
+	l.log(LevelFor(err), msg, err, fields)
+}
+
+// log writes a record if its level is enabled, the caller is the caller of the exported method
+func (l *Logger) log(level Level, msg string, err error, fields core.Fields) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if level < l.level {
+		return
+	}
+
+	record := &Record{
+		Time:    l.now(),
+		Level:   level,
+		Message: msg,
+		Caller:  common.GetCaller(5, true),
+		Error:   err,
+		Fields:  fields,
+	}
+	for _, hook := range l.hooks {
+		hook(record)
+	}
+
+	_, _ = l.out.Write(append(encode(record, l.format), '\n'))
+}
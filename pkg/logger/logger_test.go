@@ -0,0 +1,106 @@
+// (c) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/paulcarlton/go-utils/pkg/core"
+	"github.com/paulcarlton/go-utils/pkg/testutils"
+)
+
+// lineNumbers matches the line numbers of callers so they can be ignored
+var lineNumbers = regexp.MustCompile(`\.go\(\d+\)`)
+
+// newTestLogger creates a Logger writing to a buffer with a fixed time
+func newTestLogger(format Format) (*Logger, *bytes.Buffer) {
+	buf := &bytes.Buffer{}
+	l := New(buf, format)
+	l.now = func() time.Time { return time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC) }
+	return l, buf
+}
+
+func TestLevelFor(t *testing.T) {
+	var tests = []struct {
+		testNum  int
+		err      error
+		expected Level
+	}{
+		{testNum: 1, err: core.MakeError("test", core.ErrorNotFound, "not found"), expected: WarnLevel},
+		{testNum: 2, err: core.MakeError("test", core.ErrorInternal, "failed"), expected: ErrorLevel},
+		{testNum: 3, err: core.MakeError("test", core.ErrorUnknown, "failed"), expected: ErrorLevel},
+		{testNum: 4, err: errors.New("failed"), expected: ErrorLevel},
+	}
+
+	for _, test := range tests {
+		result := LevelFor(test.err)
+		if result != test.expected || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%s\nGot.....:\n%s", test.testNum, test.expected, result)
+		}
+	}
+}
+
+func TestLogfmt(t *testing.T) {
+	l, buf := newTestLogger(LogfmtFormat)
+	l.Info("started", core.Fields{"port": 8080, "name": "test service"})
+	l.Debug("not logged", nil)
+	nested := errors.New("connection refused")
+	err := core.WithFields(core.RaiseError("svc", core.ErrorServiceUnavailable, "backend unavailable", nested),
+		core.Fields{"token": "secret1"})
+	l.LogError(err, "request failed", nil)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	expected := []string{
+		`time=2019-01-02T03:04:05Z level=info msg=started caller="logger.TestLogfmt() - logger_test.go(NN)" name="test service" port=8080`,
+		`time=2019-01-02T03:04:05Z level=error msg="request failed" caller="logger.TestLogfmt() - logger_test.go(NN)"` +
+			` error="backend unavailable" code=503 codeText="Service Unavailable" id=svc` +
+			` where="logger.TestLogfmt() - logger_test.go(NN)" nested="[{\"message\":\"connection refused\"}]" token=[REDACTED]`,
+	}
+	if len(lines) != len(expected) || testutils.FailTests {
+		t.Fatalf("\nTest: 1\nExpected:\n%s\nGot.....:\n%s", strings.Join(expected, "\n"), buf.String())
+	}
+	for index := range expected {
+		if lineNumbers.ReplaceAllString(lines[index], ".go(NN)") != expected[index] || testutils.FailTests {
+			t.Errorf("\nTest: %d\nExpected:\n%s\nGot.....:\n%s", index+2, expected[index], lines[index])
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	l, buf := newTestLogger(JSONFormat)
+	list := core.NewErrorList("batch", "batch failed")
+	list.Append(core.MakeError("item1", core.ErrorNotFound, "not found"))
+	l.LogError(list, "batch failed", core.Fields{"level": "ignored", "batch": 1})
+
+	record := map[string]interface{}{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil || testutils.FailTests {
+		t.Fatalf("\nTest: 1\nExpected:\njson record\nGot.....:\n%s %s", buf.String(), err)
+	}
+	errs, ok := record["errors"].([]interface{})
+	if record["level"] != "warn" || record["code"] != float64(core.ErrorNotFound) || record["batch"] != float64(1) ||
+		!ok || len(errs) != 1 || !strings.HasPrefix(buf.String(), `{"time":"2019-01-02T03:04:05Z","level":"warn"`) || testutils.FailTests {
+		t.Errorf("\nTest: 2\nExpected:\nwarn record with errors array\nGot.....:\n%s", buf.String())
+	}
+}
+
+func TestHook(t *testing.T) {
+	l, buf := newTestLogger(JSONFormat)
+	l.SetLevel(WarnLevel)
+	records := []Record{}
+	l.AddHook(func(record *Record) {
+		records = append(records, *record)
+	})
+	l.Info("not logged", nil)
+	l.Warn("logged", core.Fields{"attempt": 2})
+
+	if len(records) != 1 || records[0].Message != "logged" || records[0].Level != WarnLevel ||
+		strings.Count(buf.String(), "\n") != 1 || testutils.FailTests {
+		t.Errorf("\nTest: 1\nExpected:\none record\nGot.....:\n%+v\n%s", records, buf.String())
+	}
+}
@@ -109,8 +109,22 @@ func UnsetEnv(t *testing.T, envName string) {
 	}
 }
 
+// errorDiffer is implemented by errors that can describe how an actual error differs from them, such as core.Error
+type errorDiffer interface {
+	DiffText(actual error) string
+}
+
 // DefaultReport is the default report test results function reports input, actual and expected as strings
+// If the expected value is an error that can describe its differences from the actual error, such as a
+// core.Error, the differences are reported in place of the actual and expected values
 func DefaultReport(t *testing.T, actual interface{}, test *DefTest) {
+	if expected, ok := test.Expected.(errorDiffer); ok {
+		if actualErr, ok := actual.(error); ok {
+			t.Errorf("\nTest: %d, %s\nInput...: %s\nDifferences...\n%s",
+				test.Number, test.Description, spew.Sdump(test.Input), expected.DiffText(actualErr))
+			return
+		}
+	}
 	t.Errorf("\nTest: %d, %s\nInput...: %s\nGot.....: %s\nExpected: %s",
 		test.Number, test.Description, spew.Sdump(test.Input), spew.Sdump(actual), spew.Sdump(test.Expected))
 }